@@ -0,0 +1,166 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"DHSimulator/rng"
+	"fmt"
+	bitops "math/bits"
+)
+
+// Bingo balls, card cells and free center cell.
+const (
+	BingoBalls = 75
+	BingoCells = 25
+	BingoFree  = 12
+)
+
+// Bingo pattern, wins when all cells of any of masks are marked.
+type BingoPattern struct {
+	Name  string   `json:"name"`  // pattern name
+	Masks []uint32 `json:"masks"` // cells masks (bit row * 5 + column)
+	Pay   float64  `json:"pay"`   // pattern pay
+}
+
+// Is any mask of pattern marked?
+func (p *BingoPattern) Won(marked uint32) bool {
+	for _, m := range p.Masks {
+		if marked&m == m {
+			return true
+		}
+	}
+	return false
+}
+
+// # Bingo
+//
+// Card of 5 x 5 cells has 5 numbers of 15 in each column (B 1-15, I 16-30,
+// N 31-45, G 46-60, O 61-75) and free center. Draw balls are called and
+// each pattern on card pays. Any mask of pattern is marked by inclusion
+// and exclusion over subsets S of masks, each union of m numbers marked
+// with hypergeometric probability:
+/*
+	prob = Σ (-1)^(|S|+1) · C(m, m) · C(75 - m, draw - m) / C(75, draw)
+*/
+type Bingo struct {
+	Draw     int            `json:"draw"`     // balls called
+	Patterns []BingoPattern `json:"patterns"` // paying patterns
+	rnd      rng.LCPRNG     // mixer
+}
+
+// Max masks of pattern (inclusion and exclusion over all subsets).
+const MaxBingoMasks = 16
+
+// Rows, columns and diagonals masks.
+func BingoLines() (lines []uint32) {
+	var diag, anti uint32
+	for i := 0; i < 5; i++ {
+		var row, col uint32
+		for j := 0; j < 5; j++ {
+			row |= 1 << (i*5 + j)
+			col |= 1 << (j*5 + i)
+		}
+		lines = append(lines, row, col)
+		diag |= 1 << (i*5 + i)
+		anti |= 1 << (i*5 + 4 - i)
+	}
+	return append(lines, diag, anti)
+}
+
+// Bingo with line, corners and full house.
+func ClassicBingo() *Bingo {
+	return &Bingo{
+		Draw: 40,
+		Patterns: []BingoPattern{
+			{Name: "line", Masks: BingoLines(), Pay: 1},
+			{Name: "corners", Masks: []uint32{1<<0 | 1<<4 | 1<<20 | 1<<24}, Pay: 6},
+			{Name: "full house", Masks: []uint32{1<<BingoCells - 1}, Pay: 5000},
+		},
+	}
+}
+
+// Initialize mixer.
+func (b *Bingo) Init(seeds ...uint64) error {
+	if b.Draw < 1 || b.Draw > BingoBalls {
+		return fmt.Errorf("bingo: bad draw %d", b.Draw)
+	}
+	for _, p := range b.Patterns {
+		if len(p.Masks) == 0 || len(p.Masks) > MaxBingoMasks {
+			return fmt.Errorf("bingo: pattern %s has %d masks", p.Name, len(p.Masks))
+		}
+	}
+	b.rnd.Solo(true) // game is used by single goroutine
+	b.rnd.Randomize(seeds...)
+	return nil
+}
+
+// Game name.
+func (b *Bingo) Title() string {
+	return fmt.Sprintf("Bingo %d balls", b.Draw)
+}
+
+// Random card by rows, 0 in free center.
+func (b *Bingo) Card() (card [BingoCells]int) {
+	for col := 0; col < 5; col++ {
+		for row, n := range b.rnd.Combination(15, 5) {
+			card[row*5+col] = 15*col + n + 1
+		}
+	}
+	card[BingoFree] = 0
+	return
+}
+
+// Drawn balls in order.
+func (b *Bingo) Draws() []int {
+	return b.rnd.Bingo()[:b.Draw]
+}
+
+// Marked cells of card.
+func (b *Bingo) Marked(card [BingoCells]int, draws []int) (mask uint32) {
+	var drawn [BingoBalls + 1]bool
+	for _, n := range draws {
+		drawn[n] = true
+	}
+	for i, n := range card {
+		if drawn[n] || i == BingoFree {
+			mask |= 1 << i
+		}
+	}
+	return
+}
+
+// Exact math by patterns.
+func (b *Bingo) Exact() (hits []DrawHit) {
+	for _, p := range b.Patterns {
+		prob := 0.
+		for s := 1; s < 1<<len(p.Masks); s++ {
+			var union uint32
+			for i, m := range p.Masks {
+				if s&(1<<i) != 0 {
+					union |= m
+				}
+			}
+			m := bitops.OnesCount32(union &^ (1 << BingoFree))
+			x := rng.MultiHypGeomDist([]int{m, BingoBalls - m}, []int{m, b.Draw - m})
+			if bitops.OnesCount(uint(s))%2 == 0 {
+				x = -x
+			}
+			prob += x
+		}
+		hits = append(hits, DrawHit{Cat: p.Name, Prob: prob, Pay: p.Pay, RTP: prob * p.Pay})
+	}
+	return
+}
+
+// Play round with random card.
+func (b *Bingo) Round(st *Tally) {
+	marked := b.Marked(b.Card(), b.Draws())
+	win := 0.
+	for _, p := range b.Patterns {
+		if p.Won(marked) {
+			st.Add(p.Name, p.Pay)
+			win += p.Pay
+		}
+	}
+	st.Play(1, win)
+}
@@ -0,0 +1,89 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import "testing"
+
+// Bingo refuses bad draw and patterns without or with too many masks.
+func TestBingoInit(t *testing.T) {
+	for _, c := range []struct {
+		name  string
+		draw  int
+		masks int
+		ok    bool
+	}{
+		{"classic", 40, 1, true},
+		{"no draw", 0, 1, false},
+		{"draw over balls", BingoBalls + 1, 1, false},
+		{"no masks", 40, 0, false},
+		{"max masks", 40, MaxBingoMasks, true},
+		{"too many masks", 40, MaxBingoMasks + 1, false},
+	} {
+		b := ClassicBingo()
+		b.Draw = c.draw
+		b.Patterns[1].Masks = make([]uint32, c.masks)
+		if err := b.Init(2024); (err == nil) != c.ok {
+			t.Errorf("%s: Init error %v", c.name, err)
+		}
+	}
+}
+
+// Cards have 5 distinct numbers of its 15 in each column and free center.
+func TestBingoCard(t *testing.T) {
+	b := ClassicBingo()
+	if err := b.Init(2024); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 1000; i++ {
+		card := b.Card()
+		var seen [BingoBalls + 1]bool
+		for cell, n := range card {
+			if cell == BingoFree {
+				if n != 0 {
+					t.Fatalf("card %v: center %d", card, n)
+				}
+				continue
+			}
+			if col := cell % 5; n < 15*col+1 || n > 15*col+15 || seen[n] {
+				t.Fatalf("card %v: bad or repeated number %d in column %d", card, n, col)
+			}
+			seen[n] = true
+		}
+	}
+}
+
+// Marked cells and won patterns of fixed draws.
+func TestBingoPatterns(t *testing.T) {
+	b := ClassicBingo()
+	var card [BingoCells]int
+	for cell := range card {
+		card[cell] = 15*(cell%5) + cell/5 + 1 // row r of column c is 15c + r + 1
+	}
+	card[BingoFree] = 0
+	all := make([]int, BingoBalls)
+	for i := range all {
+		all[i] = i + 1
+	}
+	for _, c := range []struct {
+		name   string
+		draws  []int
+		marked uint32
+		won    []bool // line, corners, full house
+	}{
+		{"nothing", []int{6, 21, 75}, 1 << BingoFree, []bool{false, false, false}},
+		{"top row", []int{1, 16, 31, 46, 61}, 0x1F | 1<<BingoFree, []bool{true, false, false}},
+		{"diagonal with free center", []int{1, 17, 49, 65}, 1<<0 | 1<<6 | 1<<12 | 1<<18 | 1<<24, []bool{true, false, false}},
+		{"corners", []int{1, 61, 5, 65}, 1<<0 | 1<<4 | 1<<12 | 1<<20 | 1<<24, []bool{false, true, false}},
+		{"full house", all, 1<<BingoCells - 1, []bool{true, true, true}},
+	} {
+		marked := b.Marked(card, c.draws)
+		if marked != c.marked {
+			t.Errorf("%s: marked %025b, want %025b", c.name, marked, c.marked)
+		}
+		for i, p := range b.Patterns {
+			if won := p.Won(marked); won != c.won[i] {
+				t.Errorf("%s: %s won %v", c.name, p.Name, won)
+			}
+		}
+	}
+}
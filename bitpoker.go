@@ -6,13 +6,19 @@ import (
 	"DHSimulator/rng"
 	"errors"
 	"fmt"
-	"time"
 )
 
 type bits = uint64
 
 const standard_pack bits = (1 << 52) - 1 // 52 ones
 
+// Poker errors.
+var (
+	ErrBadPack   = errors.New("poker: pack must have at least 5 cards")
+	ErrBadOrder  = errors.New("poker: order must be permutation of 10 hands")
+	ErrCardCount = errors.New("poker: invalid number of cards")
+)
+
 /*
 	AAAA KKKK QQQQ JJJJ TTTT 9999 8888 7777 6666 5555 4444 3333 2222
 	♣♥♦♠ ♣♥♦♠ ♣♥♦♠ ♣♥♦♠ ♣♥♦♠ ♣♥♦♠ ♣♥♦♠ ♣♥♦♠ ♣♥♦♠ ♣♥♦♠ ♣♥♦♠ ♣♥♦♠ ♣♥♦♠
@@ -25,17 +31,19 @@ type BitPoker struct {
 	pack  bits       // pack of cards
 	order [10]int    // hands order
 	power [10]int    // hands strength
-	Wheel bool       // Is 5432A = straight?
+	Wheel bool       // Is ace with 4 lowest kinds of pack (5432A) straight?
 	Kinds [13]string // kinds
 	Suits [4]string  // suits
 	Names [10]string // hands names
+	lut   *rankTable // rank tables
 }
 
 // Init poker game.
 func (bp *BitPoker) Init(pack bits, wheel bool, order []int, maps ...[]string) (err error) {
 	bp.pack = pack & standard_pack
+	bp.lut = nil
 	if l := bp.Length(bp.pack); l < 5 {
-		err = errors.New("unexpectedError")
+		err = fmt.Errorf("%w: %d cards", ErrBadPack, l)
 	}
 
 	bp.Wheel = wheel
@@ -43,7 +51,7 @@ func (bp *BitPoker) Init(pack bits, wheel bool, order []int, maps ...[]string) (
 	copy(bp.order[:], order)
 	const order_mask bits = 1<<10 - 1 // 10 ones
 	if len(order) != 10 || bp.Deflate(order) != order_mask {
-		err = errors.New("unexpectedError")
+		err = fmt.Errorf("%w: %v", ErrBadOrder, order)
 	} else {
 		for p, o := range bp.order {
 			bp.power[o] = p
@@ -201,13 +209,33 @@ There are 7462 different hands:
 	9EDCBA royal flush (strongest)
 */
 func (bp *BitPoker) Hand(hold bits) string {
+	if bp.ensure(); bp.Length(hold&bp.pack) != 5 {
+		return ""
+	}
+	return bp.View(bp.Rank(hold))
+}
+
+// Kinds mask of ace with 4 lowest kinds of pack (kinds from 1).
+func (bp *BitPoker) wheel() int {
+	mask, n := 1<<13, 0
+	for k := 0; k < 12 && n < 4; k++ {
+		if bp.pack>>(4*k)&0xF != 0 {
+			mask |= 1 << (k + 1)
+			n++
+		}
+	}
+	return mask
+}
+
+// Reference hand evaluator (maps and sorting), used to build rank tables.
+func (bp *BitPoker) handCode(hold bits) string {
 	const (
 		kenta int = 0b11111          // 5 consecutive kinds
-		A5432 int = 0b10000000011110 // A5432 mask
 		royal int = 0b11111000000000 // AKQJT mask
 	)
 
 	bp.ensure()
+	A5432 := bp.wheel() // A5432 mask (A9876 for 6+)
 
 	result, cards := "", bp.Inflate(hold&bp.pack)
 
@@ -292,36 +320,46 @@ func (bp *BitPoker) Hand(hold bits) string {
 	return result
 }
 
-// Returns poker hand strength.
+// Returns poker hand strength (rank of hand code), -1 for unknown code.
+//
+// Strength is rank 1 to Ranks() (7462 for Classic poker). It used to be
+// hand code digits S and XXXXX read as hexadecimal number times 10 plus
+// hand code H, so old stored strengths do not compare with new ones.
 func (bp *BitPoker) HandStrength(code string) int {
-	if code == "" {
-		return -1
-	}
-	s := 0
-	for i := 0; i < 6; i++ {
-		s = 16*s + number(code[i])
+	if r := bp.rankOf(code); r > 0 {
+		return r
 	}
-	s = 10*s + number(code[7])
-	return s
+	return -1
 }
 
-// Returns poker hand code only.
+// Returns poker hand code only, -1 for unknown code.
 func (bp *BitPoker) HandCode(hand string) int {
-	if hand == "" {
-		return -1
-	}
-	return number(hand[7])
+	return bp.Category(bp.rankOf(hand))
 }
 
 // Returns poker hand code only, from 0 to 9.
 func (bp *BitPoker) Code(hold bits) int {
-	return bp.HandCode(bp.Hand(hold))
+	if bp.ensure(); bp.Length(hold&bp.pack) != 5 {
+		return -1
+	}
+	return bp.Category(bp.Rank(hold))
 }
 
 // Best poker hand with keep number of cards from hold and rest from desk.
 //
 // Returns hand code and selected cards from hold and desk.
 func (bp *BitPoker) Holdem(hold, desk bits, keep int) (mc string, mh, md bits) {
+	mr := 0
+	if mr, mh, md = bp.holdem(hold, desk, keep); mr > 0 {
+		mc = bp.View(mr)
+	}
+	return // code, hold, desk
+}
+
+// Best poker hand rank with keep number of cards from hold and rest from desk.
+//
+// Returns rank (0 if none) and selected cards from hold and desk.
+func (bp *BitPoker) holdem(hold, desk bits, keep int) (mr int, mh, md bits) {
 	bp.ensure()                             // ensure pack
 	hold, desk = hold&bp.pack, desk&bp.pack // remove non-standard cards (if any)
 	desk ^= hold & desk                     // remove duplicates from desk (if any)
@@ -329,7 +367,7 @@ func (bp *BitPoker) Holdem(hold, desk bits, keep int) (mc string, mh, md bits) {
 	var sh, sd sampler // hold and desk samples
 
 	if sh.Init(hold, keep) && sd.Init(desk, 5-keep) {
-		mb := mh
+		var mb bits
 		for !sh.Eof() {
 			h := sh.Next() // keep cards from hold
 
@@ -338,14 +376,14 @@ func (bp *BitPoker) Holdem(hold, desk bits, keep int) (mc string, mh, md bits) {
 				d := sd.Next() // 5-keep cards from desk
 
 				b := h | d // test for the best
-				if c := bp.Hand(b); c > mc || (c == mc && b > mb) {
-					mc, mh, md, mb = c, h, d, b // current best hand
+				if r := bp.Rank(b); r > mr || (r == mr && b > mb) {
+					mr, mh, md, mb = r, h, d, b // current best hand
 				}
 			}
 		}
 	}
 
-	return // code, hold, desk
+	return // rank, hold, desk
 }
 
 // Best poker hand from all hold cards and rest from desk.
@@ -441,14 +479,7 @@ func (bp *BitPoker) Arange(pile bits) []int {
 	pile &= bp.pack
 	d := bp.Inflate(pile)
 	if l := len(d); l == 5 {
-		p := bp.Hand(pile)
-		b := []byte(p[1:5])
-		for i, c := range b {
-			k := number(c) - 1 // get kind
-			if k == 0 {
-				k = 13 // bicycle
-			}
-			k--
+		for i, k := range bp.RankOf(bp.Rank(pile)).Kickers {
 			for j := 4; j > i; j-- { // find card
 				if d[j]>>2 == k {
 					k = d[j]
@@ -510,29 +541,30 @@ func (bp *BitPoker) Expand(pile bits) []int {
 	return a
 }
 
-// Determines players standing list according to given rule.
-func (bp *BitPoker) PlayPokerHand(players []bits, desk bits, rule func(hold, desk bits) (string, bits, bits)) (codes []string, stand [][]int) {
+// Standings of players by rank of their hands (greater is better),
+// from best to worst, with hand code of each place.
+func standings(players int, hand func(p int) (code string, rank int)) (codes []string, stand [][]int) {
 	// calculate hands and sort list
-	type hand struct {
-		p    int    // player
-		c    string // code
-		h, d bits   // hold, desk (future use)
+	type entry struct {
+		p int    // player
+		c string // code
+		r int    // rank of code
 	}
-	hands := make([]hand, len(players))
-	for p, hold := range players {
-		c, h, d := rule(hold, desk)
-		x := hand{p, c, h, d}
-		for q := p - 1; p > 0 && hands[q].c < c; q-- { // insertion sort
+	hands := make([]entry, players)
+	for p := range hands {
+		c, r := hand(p)
+		x := entry{p, c, r}
+		for q := p - 1; p > 0 && hands[q].r < x.r; q-- { // insertion sort
 			p, hands[p] = q, hands[q]
 		}
 		hands[p] = x
 	}
 
-	// calculate standongs
-	c, p := "", -1
+	// calculate standings
+	r, p := 0, -1
 	for i, x := range hands {
-		if c != x.c || i == 0 {
-			c, p, codes, stand = x.c, p+1, append(codes, x.c), append(stand, []int{})
+		if r != x.r || i == 0 {
+			r, p, codes, stand = x.r, p+1, append(codes, x.c), append(stand, []int{})
 		}
 		stand[p] = append(stand[p], x.p)
 	}
@@ -540,6 +572,16 @@ func (bp *BitPoker) PlayPokerHand(players []bits, desk bits, rule func(hold, des
 	return
 }
 
+// Determines players standing list according to given rule.
+//
+// Hands are compared by rank, standings go from best to worst.
+func (bp *BitPoker) PlayPokerHand(players []bits, desk bits, rule func(hold, desk bits) (string, bits, bits)) ([]string, [][]int) {
+	return standings(len(players), func(p int) (string, int) {
+		code, _, _ := rule(players[p], desk)
+		return code, bp.rankOf(code)
+	})
+}
+
 // Determines players standing list according to Texas Holdem rule.
 func (bp *BitPoker) PlayTexasHand(players []bits, desk bits) ([]string, [][]int) {
 	return bp.PlayPokerHand(players, desk, bp.TexasHoldem)
@@ -856,11 +898,12 @@ func (pok *Poker) Hand(hold []int) string {
 
 // Returns poker hand code only.
 func (pok *Poker) HandCode(hand string) int {
-	if hand == "" {
-		return -1
-	} else {
-		return number(hand[7])
-	}
+	return pok.bp.HandCode(hand)
+}
+
+// Typed rank of hand of 5 to 7 cards.
+func (pok *Poker) HandRank(hold []int) (HandRank, error) {
+	return pok.bp.HandRank(pok.bp.Squeeze(hold...))
 }
 
 // Returns poker hand code only, from 0 to 9.
@@ -953,33 +996,11 @@ func (pok *Poker) OmahaHoldem(hold, desk []int) (string, []int, []int) {
 }
 
 // Determines players standing list according to given rule.
-func (pok *Poker) PlayPokerHand(players [][]int, desk []int, rule func(hold, desk []int) (string, []int, []int)) (codes []string, stand [][]int) {
-	// calculate hands and sort list
-	type hand struct {
-		p    int    // player
-		c    string // code
-		h, d []int  // hold, desk (future use)
-	}
-	hands := make([]hand, len(players))
-	for p, hold := range players {
-		c, h, d := rule(hold, desk)
-		x := hand{p, c, h, d}
-		for q := p - 1; p > 0 && hands[q].c < c; q-- { // insertion sort
-			p, hands[p] = q, hands[q]
-		}
-		hands[p] = x
-	}
-
-	// calculate standongs
-	c, p := "", -1
-	for i, x := range hands {
-		if c != x.c || i == 0 {
-			c, p, codes, stand = x.c, p+1, append(codes, x.c), append(stand, []int{})
-		}
-		stand[p] = append(stand[p], x.p)
-	}
-
-	return
+func (pok *Poker) PlayPokerHand(players [][]int, desk []int, rule func(hold, desk []int) (string, []int, []int)) ([]string, [][]int) {
+	return standings(len(players), func(p int) (string, int) {
+		code, _, _ := rule(players[p], desk)
+		return code, pok.bp.rankOf(code)
+	})
 }
 
 // Determines players standing list according to Texas Holdem rule.
@@ -1015,19 +1036,18 @@ var WildCardComb = [6][10]int{
 	{0, 0, 0, 0, 0, 0, 0, 0, 32, 20},
 	{0, 0, 0, 0, 0, 0, 0, 0, 0, 1}}
 
+// Hands counts by code of all 5 cards completions of hold (Enumerate),
+// or of all 5 cards subsets of hold with more than 5 cards.
 func (bp *BitPoker) Likelihood(hold bits) (list []int, sum int) {
 	bp.ensure() // ensure pack
 
 	hold &= bp.pack // remove non-standard cards (if any)
 
-	start := time.Now()
-
 	if l := bp.Length(hold); l <= 5 {
-		var wild sampler
-		if wild.Init(bp.Rest(hold), 5-l) {
+		if o, err := bp.Enumerate(FiveCardRule, hold, 0); err == nil && o.Total > 0 {
 			list = make([]int, 10)
-			for !wild.Eof() {
-				list[bp.Code(hold|wild.Next())]++
+			for c, n := range o.Count {
+				list[c] = int(n)
 			}
 		}
 	} else {
@@ -1041,10 +1061,6 @@ func (bp *BitPoker) Likelihood(hold bits) (list []int, sum int) {
 	for _, l := range list {
 		sum += l
 	}
-
-	elapsed := time.Since(start).Seconds()
-	fmt.Println(elapsed)
-
 	return
 }
 
@@ -1052,6 +1068,11 @@ func (pok *Poker) Likelihood(hold []int) ([]int, int) {
 	return pok.bp.Likelihood(pok.bp.Squeeze(hold...))
 }
 
+// Hands with w wild cards: card counts each hand of classic pack once per
+// w of its cards, wild counts best hand of each 5 - w cards with w wilds.
+//
+// Not done by Enumerate, which counts categories of all completions but
+// not best completion of each partial hand.
 func CountCombs(w int) (card, wild [10]int) {
 	var poker BitPoker
 	poker.Classic()
@@ -1084,6 +1105,9 @@ func CountCombs(w int) (card, wild [10]int) {
 	return
 }
 
+// Worst and best hands (as Hand codes) keeping min or more cards of hold.
+//
+// Not done by Enumerate, which counts categories but not hands themselves.
 func (bp *BitPoker) MinMax(hold bits, min int) (worst, best string) {
 	bp.ensure() // ensure pack
 	if min < 2 {
@@ -1126,12 +1150,18 @@ func GCD(u, v uint64) uint64 {
 	return u
 }
 
+// Print exact hands probabilities of partial hand (Enumerate) or of hand
+// with more than 5 cards (Likelihood).
 func HandProb(wheel bool, h ...int) {
 	var p Poker
 	p.Classic()
 	p.bp.Wheel = wheel
 
 	l, s := p.Likelihood(h)
+	if s == 0 {
+		fmt.Println("no hands of", p.Stringify(h))
+		return
+	}
 	fmt.Println()
 	fmt.Print(p.Stringify(h))
 	if wheel {
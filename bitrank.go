@@ -0,0 +1,319 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"fmt"
+	bitops "math/bits"
+	"sort"
+)
+
+// # Perfect hash of kinds multiset
+//
+// Kinds counts (0 to 4) of n cards pile are digits of base 5 number,
+// multisets in lexicographic order get consecutive indexes:
+/*
+	index = Σ  Σ     N(12 - k, n - c0 - ... - c(k-1) - v)
+	        k  v<c(k)
+*/
+// where N(j, m) is number of j digits numbers with digits sum m.
+// Inner sums are precomputed in kindsOffset[k][c(k)][remaining sum].
+var kindsOffset = func() (off [13][5][8]uint16) {
+	var ways [14][8]int // ways[j][m] = N(j, m)
+	ways[0][0] = 1
+	for j := 1; j <= 13; j++ {
+		for m := 0; m < 8; m++ {
+			for v := 0; v <= 4 && v <= m; v++ {
+				ways[j][m] += ways[j-1][m-v]
+			}
+		}
+	}
+	for k := 0; k < 13; k++ {
+		for c := 1; c <= 4; c++ {
+			for m := 0; m < 8; m++ {
+				off[k][c][m] = off[k][c-1][m]
+				if v := c - 1; v <= m {
+					off[k][c][m] += uint16(ways[12-k][m-v])
+				}
+			}
+		}
+	}
+	return
+}()
+
+// Index of kinds multiset of n cards pile among n cards multisets.
+//
+// Remaining sum at kind k is number of cards of kind k and above,
+// so present kinds are added independently.
+func kindsIndex(pile bits) (index int) {
+	for p := pile; p != 0; {
+		k := bitops.TrailingZeros64(p) >> 2
+		m := p >> (4 * k)
+		index += int(kindsOffset[k][bitops.OnesCount64(m&0xF)][bitops.OnesCount64(m)])
+		p &^= 0xF << (4 * k)
+	}
+	return
+}
+
+// # Hand rank lookup tables
+//
+// Built once per pack, order and wheel from reference evaluator,
+// so ranks agree with hand codes by construction.
+type rankTable struct {
+	pack  bits            // pack of cards
+	wheel bool            // wheel
+	codes []string        // hand code by rank ("SXXXXX H"), codes[0] = ""
+	hands []HandRank      // typed hand by rank
+	index map[string]int  // rank by hand code
+	flush [1 << 13]uint16 // best flush rank by kinds mask (5 to 7 kinds)
+	plain [8][]uint16     // best non-flush rank by cards count and kinds index (5 to 7 cards)
+}
+
+// Rank tables for current game (built on first use).
+//
+// BitPoker is not safe for concurrent use until tables are built,
+// so call Rank once before sharing it between goroutines.
+func (bp *BitPoker) table() *rankTable {
+	bp.ensure()
+	if t := bp.lut; t != nil && t.wheel == bp.Wheel { // Init drops tables
+		return t
+	}
+	bp.lut = bp.buildRanks()
+	return bp.lut
+}
+
+// Build rank tables.
+func (bp *BitPoker) buildRanks() *rankTable {
+	t := &rankTable{pack: bp.pack, wheel: bp.Wheel}
+
+	var suits [13]bits // available cards by kind
+	for k := range suits {
+		suits[k] = bp.pack >> (4 * k) & 0xF
+	}
+
+	// non-flush representative of kinds multiset, false if none in pack
+	plain := func(count []int) (pile bits, ok bool) {
+		var sm bits // suits used
+		for k, c := range count {
+			s := suits[k]
+			if bp.Length(s) < c {
+				return 0, false
+			}
+			for ; c > 0; c-- {
+				b := s & -s
+				pile |= b << (4 * k)
+				sm |= b
+				s &= s - 1
+			}
+		}
+		if bp.Length(sm) == 1 { // accidental flush, try other suit
+			for k, c := range count {
+				if o := suits[k] &^ sm; c == 1 && o != 0 {
+					return pile&^(sm<<(4*k)) | (o&-o)<<(4*k), true
+				}
+			}
+			return 0, false
+		}
+		return pile, true
+	}
+
+	// 5 cards classes
+	rank := map[string][]int{} // hand code -> keys (flush keys are masks | 1 << 62)
+	const flushKey = 1 << 62
+	var multisets [8][][]int // kinds counts by size
+	count := make([]int, 13)
+	var scan func(k, n int)
+	scan = func(k, n int) {
+		if k == 13 {
+			if n >= 5 {
+				multisets[n] = append(multisets[n], append([]int{}, count...))
+			}
+			return
+		}
+		for c := 0; c <= 4 && n+c <= 7 && c <= bp.Length(suits[k]); c++ {
+			count[k] = c
+			scan(k+1, n+c)
+		}
+		count[k] = 0
+	}
+	scan(0, 0)
+
+	key := func(count []int) int {
+		var pile bits // any cards with these kinds
+		for k, c := range count {
+			pile |= (1<<c - 1) << (4 * k)
+		}
+		return kindsIndex(pile)
+	}
+	for n := 5; n <= 7; n++ {
+		if m := multisets[n]; len(m) > 0 {
+			t.plain[n] = make([]uint16, key(m[len(m)-1])+1) // last in lexicographic order
+		}
+	}
+
+	for _, m := range multisets[5] {
+		if pile, ok := plain(m); ok {
+			c := bp.handCode(pile)[:8]
+			rank[c] = append(rank[c], key(m))
+		}
+	}
+	for mask := 0; mask < 1<<13; mask++ {
+		if bitops.OnesCount(uint(mask)) == 5 {
+			for s := 0; s < 4; s++ { // suit with all kinds
+				var pile bits
+				for k := 0; k < 13; k++ {
+					if mask>>k&1 != 0 {
+						pile |= 1 << (4*k + s)
+					}
+				}
+				if pile&bp.pack == pile {
+					c := bp.handCode(pile)[:8]
+					rank[c] = append(rank[c], flushKey|mask)
+					break
+				}
+			}
+		}
+	}
+
+	// ranks in order of codes
+	t.codes = []string{""}
+	for c := range rank {
+		t.codes = append(t.codes, c)
+	}
+	sort.Strings(t.codes)
+	t.hands, t.index = make([]HandRank, len(t.codes)), map[string]int{}
+	for r, c := range t.codes {
+		if r > 0 { // the only place hand code is parsed
+			h := HandRank{Rank: r, Category: number(c[7]), Strength: number(c[0])}
+			for i := range h.Kickers {
+				h.Kickers[i] = (number(c[1+i]) + 11) % 13 // ace is 1 in bicycle
+			}
+			t.hands[r], t.index[c] = h, r
+		}
+		for _, k := range rank[c] {
+			if k&flushKey != 0 {
+				t.flush[k&^flushKey] = uint16(r)
+			} else {
+				t.plain[5][k] = uint16(r)
+			}
+		}
+	}
+
+	// 6 and 7 cards: best of 5 (one card less at time)
+	for n := 6; n <= 7; n++ {
+		for _, m := range multisets[n] {
+			best := uint16(0)
+			for k, c := range m {
+				if c > 0 {
+					m[k]--
+					if r := t.plain[n-1][key(m)]; r > best {
+						best = r
+					}
+					m[k]++
+				}
+			}
+			t.plain[n][key(m)] = best
+		}
+	}
+	for mask := 0; mask < 1<<13; mask++ {
+		if n := bitops.OnesCount(uint(mask)); n == 6 || n == 7 {
+			best := uint16(0)
+			for m := mask; m != 0; m &= m - 1 {
+				if r := t.flush[mask&^(m&-m)]; r > best {
+					best = r
+				}
+			}
+			t.flush[mask] = best
+		}
+	}
+
+	return t
+}
+
+// Hand rank of 5, 6 or 7 cards pile (best 5 cards), 0 for other piles.
+//
+// Ranks are 1 (weakest) to Ranks() (strongest), 7462 for Classic poker.
+// Assumes flush is never weaker than same kinds without flush.
+func (bp *BitPoker) Rank(pile bits) int {
+	t := bp.table()
+	pile &= t.pack
+	n := bitops.OnesCount64(pile)
+	if n < 5 || n > 7 {
+		return 0
+	}
+	r := t.plain[n][kindsIndex(pile)]
+	for s := 0; s < 4; s++ {
+		if sp := pile >> s & 0x1111111111111; bitops.OnesCount64(sp) >= 5 {
+			m := 0 // kinds mask of suit
+			for ; sp != 0; sp &= sp - 1 {
+				m |= 1 << (bitops.TrailingZeros64(sp) >> 2)
+			}
+			if f := t.flush[m]; f > r {
+				r = f
+			}
+		}
+	}
+	return int(r)
+}
+
+// Number of different hands (highest rank).
+func (bp *BitPoker) Ranks() int {
+	return len(bp.table().codes) - 1
+}
+
+// Hand code view of rank in format "SXXXXX H hand name", "" for invalid rank.
+func (bp *BitPoker) View(rank int) string {
+	t := bp.table()
+	if rank <= 0 || rank >= len(t.codes) {
+		return ""
+	}
+	return t.codes[rank] + " " + bp.Names[t.hands[rank].Category]
+}
+
+// Hand code (0 to 9) of rank, -1 for invalid rank.
+func (bp *BitPoker) Category(rank int) int {
+	t := bp.table()
+	if rank <= 0 || rank >= len(t.codes) {
+		return -1
+	}
+	return t.hands[rank].Category
+}
+
+// # Typed poker hand
+//
+// Hands compare, sort and serialize by Rank.
+type HandRank struct {
+	Rank     int    `json:"rank"`     // 1 (weakest) to Ranks() (strongest), 0 for no hand
+	Category int    `json:"category"` // hand code (0 to 9)
+	Strength int    `json:"strength"` // position of hand code in hands order
+	Kickers  [5]int `json:"kickers"`  // kinds in order of significance (0 = 2, 12 = A)
+	Name     string `json:"name"`     // hand name
+}
+
+// Typed hand of rank, zero value for invalid rank.
+func (bp *BitPoker) RankOf(rank int) HandRank {
+	t := bp.table()
+	if rank <= 0 || rank >= len(t.codes) {
+		return HandRank{}
+	}
+	h := t.hands[rank]
+	h.Name = bp.Names[h.Category]
+	return h
+}
+
+// Typed hand of 5, 6 or 7 cards pile (best 5 cards).
+func (bp *BitPoker) HandRank(pile bits) (HandRank, error) {
+	bp.ensure()
+	if n := bp.Length(pile & bp.pack); n < 5 || n > 7 {
+		return HandRank{}, fmt.Errorf("%w: %d cards, 5 to 7 expected", ErrCardCount, n)
+	}
+	return bp.RankOf(bp.Rank(pile)), nil
+}
+
+// Rank of hand code ("SXXXXX H" with or without name), 0 if unknown.
+func (bp *BitPoker) rankOf(code string) int {
+	if len(code) < 8 {
+		return 0
+	}
+	return bp.table().index[code[:8]]
+}
@@ -0,0 +1,150 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"DHSimulator/rng"
+	"errors"
+	"testing"
+)
+
+// Poker games of rank tables tests.
+var rankGames = []struct {
+	name  string
+	setup func(bp *BitPoker)
+}{
+	{"Classic", (*BitPoker).Classic},
+	{"SixUp", (*BitPoker).SixUp},
+	{"SevenUp", (*BitPoker).SevenUp},
+}
+
+// Rank tables against reference evaluator: all 5 cards hands
+// and sample of 6 and 7 cards piles against best 5 of them.
+func TestRankTables(t *testing.T) {
+	const samples = 100 * 1000
+	var rnd rng.LCPRNG
+	rnd.Solo(true) // own generator, Dealer sequence is untouched
+	rnd.Randomize(2024)
+	for _, game := range rankGames {
+		var bp BitPoker
+		game.setup(&bp)
+		var s sampler
+		s.Init(bp.pack, 5)
+		for !s.Eof() {
+			h := s.Next()
+			if a, b := bp.Hand(h), bp.handCode(h); a != b {
+				t.Fatalf("%s: %x rank %q, reference %q", game.name, h, a, b)
+			}
+		}
+
+		cards := bp.PackOfCards()
+		for i := 0; i < samples; i++ {
+			pile := bp.Squeeze(rnd.Sample(6+i%2, cards)...)
+			best := -1
+			var five sampler
+			five.Init(pile, 5)
+			for !five.Eof() {
+				if r := bp.Rank(five.Next()); r > best {
+					best = r
+				}
+			}
+			if r := bp.Rank(pile); r != best {
+				t.Fatalf("%s: %x rank %d, best of 5 %d", game.name, pile, r, best)
+			}
+		}
+	}
+}
+
+// Rank of all 5 cards hands by tables.
+func BenchmarkRank(b *testing.B) {
+	for _, game := range rankGames {
+		b.Run(game.name, func(b *testing.B) {
+			var bp BitPoker
+			game.setup(&bp)
+			bp.Rank(0) // build tables
+			var s sampler
+			s.Init(bp.pack, 5)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if s.Eof() {
+					s.Reset()
+				}
+				bp.Rank(s.Next())
+			}
+		})
+	}
+}
+
+// Init and HandRank report bad pack, bad order and bad card count.
+func TestPokerErrors(t *testing.T) {
+	order := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	var bp BitPoker
+	for _, c := range []struct {
+		name  string
+		pack  bits
+		order []int
+		err   error
+	}{
+		{"valid", standard_pack, order, nil},
+		{"4 cards pack", 0xF, order, ErrBadPack},
+		{"short order", standard_pack, order[:9], ErrBadOrder},
+		{"repeated order", standard_pack, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 8}, ErrBadOrder},
+	} {
+		if err := bp.Init(c.pack, true, c.order); !errors.Is(err, c.err) || (err == nil) != (c.err == nil) {
+			t.Errorf("%s: Init error %v, want %v", c.name, err, c.err)
+		}
+	}
+
+	bp.Classic()
+	for _, c := range []struct {
+		cards string
+		err   error
+	}{
+		{"AsKsQsJs", ErrCardCount},
+		{"AsKsQsJsTs", nil},
+		{"AsKsQsJsTs9s8s", nil},
+		{"AsKsQsJsTs9s8s7s", ErrCardCount},
+	} {
+		if _, err := bp.HandRank(parsePile(t, &bp, c.cards)); !errors.Is(err, c.err) || (err == nil) != (c.err == nil) {
+			t.Errorf("%s: HandRank error %v, want %v", c.cards, err, c.err)
+		}
+	}
+}
+
+// HandRank category, kickers and name of best 5 cards.
+func TestHandRank(t *testing.T) {
+	var bp BitPoker
+	bp.Classic()
+	for _, c := range []struct {
+		cards    string
+		category int
+		kickers  [5]int
+	}{
+		{"AhKhQhJhTh", 9, [5]int{12, 11, 10, 9, 8}},
+		{"5s4s3s2sAs", 8, [5]int{3, 2, 1, 0, 12}},
+		{"KsKdKhQcQs", 6, [5]int{11, 11, 11, 10, 10}},
+		{"As2d3h4c5s", 4, [5]int{3, 2, 1, 0, 12}},
+		{"9s9d4h4c2s", 2, [5]int{7, 7, 2, 2, 0}},
+		{"7s5d4h3c2s9d", 0, [5]int{7, 5, 3, 2, 1}},
+		{"AsKdQh8c3s2d7c", 0, [5]int{12, 11, 10, 6, 5}},
+	} {
+		h, err := bp.HandRank(parsePile(t, &bp, c.cards))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if h.Category != c.category || h.Strength != c.category || h.Kickers != c.kickers || h.Name != bp.Names[c.category] {
+			t.Errorf("%s: %+v, want category %d kickers %v", c.cards, h, c.category, c.kickers)
+		}
+		if r := bp.RankOf(h.Rank); r != h {
+			t.Errorf("%s: RankOf(%d) = %+v, want %+v", c.cards, h.Rank, r, h)
+		}
+	}
+	if h := bp.RankOf(bp.Ranks()); h.Category != 9 {
+		t.Errorf("strongest rank %+v, want royal flush", h)
+	}
+	for _, r := range []int{0, -1, bp.Ranks() + 1} {
+		if h := bp.RankOf(r); h != (HandRank{}) {
+			t.Errorf("RankOf(%d) = %+v, want zero", r, h)
+		}
+	}
+}
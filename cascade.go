@@ -0,0 +1,221 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"fmt"
+)
+
+// Max cascades of single spin (guard against endless tumbling).
+var MaxCascades = 1000
+
+// # Cluster pays
+//
+// Groups of at least min connected cells (up, down, left or right) of
+// same symbol or wilds win. Width is cluster size, Mask its cells and
+// Multi product of multipliers of wilds in cluster. Wilds only groups
+// do not win. Reels may be of different heights.
+func Clusters(grid [][]symbol, min int, wilds ...Wild) (result []WaysItemNew) {
+	multi := map[symbol]int{}
+	for _, w := range wilds {
+		multi[w.Symbol] = w.multi()
+	}
+	seen := make([][]bool, len(grid)) // cells of symbol in found clusters
+	for i, reel := range grid {
+		seen[i] = make([]bool, len(reel))
+	}
+	type cell struct{ reel, row int }
+
+	for i, reel := range grid {
+		for r, s := range reel {
+			if _, wild := multi[s]; wild || seen[i][r] {
+				continue
+			}
+			win := WaysItemNew{Symbol: s, Ways: 1, Multi: 1, Payline: -1, Mask: make([]uint, len(grid))}
+			stack := []cell{{i, r}}
+			win.Mask[i] |= 1 << r
+			for len(stack) > 0 {
+				c := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				win.Width++
+				if m, wild := multi[grid[c.reel][c.row]]; wild {
+					win.Multi *= m
+				} else {
+					seen[c.reel][c.row] = true
+				}
+				for _, n := range []cell{{c.reel - 1, c.row}, {c.reel + 1, c.row}, {c.reel, c.row - 1}, {c.reel, c.row + 1}} {
+					if n.reel < 0 || n.reel >= len(grid) || n.row < 0 || n.row >= len(grid[n.reel]) || win.Mask[n.reel]&(1<<n.row) != 0 {
+						continue
+					}
+					x := grid[n.reel][n.row]
+					if _, wild := multi[x]; wild || x == s {
+						win.Mask[n.reel] |= 1 << n.row
+						stack = append(stack, n)
+					}
+				}
+			}
+			if win.Width >= min {
+				result = append(result, win)
+			}
+		}
+	}
+	return
+}
+
+// Cluster win of symbol by cluster size (larger clusters pay as largest in table).
+func (sl *Slot) ClusterPay(s symbol, size int) float64 {
+	p := sl.ClusterPays[s]
+	if len(p) == 0 {
+		return 0
+	}
+	if size >= len(p) {
+		size = len(p) - 1
+	}
+	return p[size]
+}
+
+// Paying clusters.
+func (sl *Slot) clusters(grid [][]symbol) (wins []WaysItemNew) {
+	if sl.Cluster <= 0 {
+		return
+	}
+	for _, w := range Clusters(grid, sl.Cluster, sl.wilds()...) {
+		if w.Symbol == sl.Scatter {
+			continue
+		}
+		if w.Line = sl.ClusterPay(w.Symbol, w.Width); w.Line > 0 {
+			w.Payout = w.Line * float64(w.Multi)
+			wins = append(wins, w)
+		}
+	}
+	return
+}
+
+// Progressive multiplier of cascade step (0 is spin itself).
+func (sl *Slot) cascadeMulti(step int) float64 {
+	if len(sl.Multipliers) == 0 {
+		return 1
+	}
+	if step >= len(sl.Multipliers) {
+		step = len(sl.Multipliers) - 1
+	}
+	return sl.Multipliers[step]
+}
+
+// Remove winning cells, drop rest of reel down and fill reel from top with
+// strip symbols above window (cursor is strip index of top cell).
+func (sl *Slot) drop(grid [][]symbol, mask []uint, cursor []int) [][]symbol {
+	out := make([][]symbol, len(grid))
+	for i, reel := range grid {
+		var rest []symbol
+		for r, s := range reel {
+			if mask[i]&(1<<r) == 0 {
+				rest = append(rest, s)
+			}
+		}
+		strip := sl.Reels[i]
+		k := len(reel) - len(rest)
+		cursor[i] = ((cursor[i]-k)%len(strip) + len(strip)) % len(strip)
+		for j := 0; j < k; j++ {
+			out[i] = append(out[i], strip[(cursor[i]+j)%len(strip)])
+		}
+		out[i] = append(out[i], rest...)
+	}
+	return out
+}
+
+// # Cascading reels
+//
+// Winning cells of spin are removed, symbols above fall down and new ones
+// drop from reel strips (continuing above stops), new window is evaluated
+// again (after expanding wilds) with progressive multiplier, until there is
+// no win. Scatters stay and pay once on final window.
+func (sl *Slot) tumble(spin *Spin) {
+	cursor := append([]int{}, spin.Stops...)
+	step := *spin
+	spin.Wins, spin.Win = nil, 0
+	for k := 0; k < MaxCascades; k++ {
+		step.Multi = sl.cascadeMulti(k)
+		step.Win = 0
+		mask := make([]uint, len(step.Grid))
+		for w := range step.Wins {
+			step.Wins[w].Payout *= step.Multi
+			step.Win += step.Wins[w].Payout
+			for i, m := range step.Wins[w].Mask {
+				mask[i] |= m
+			}
+		}
+		spin.Steps = append(spin.Steps, step)
+		spin.Wins = append(spin.Wins, step.Wins...)
+		spin.Win += step.Win
+		if len(step.Wins) == 0 {
+			break
+		}
+		grid := sl.expand(sl.drop(step.Grid, mask, cursor))
+		step = Spin{Grid: grid, Wins: sl.wins(grid)}
+	}
+	last := spin.Steps[len(spin.Steps)-1].Grid
+	spin.Scatter, spin.Bonus = sl.scatters(last)
+	spin.Win += spin.Bonus
+}
+
+// Print cascade steps.
+func (spin *Spin) PrintSteps(sl *Slot) {
+	for k, step := range spin.Steps {
+		fmt.Printf("step %d  x%g\n", k, step.Multi)
+		step.Print(sl)
+	}
+	if spin.Bonus > 0 {
+		fmt.Printf("%-8s x%d  %.2f\n", sl.Symbol(sl.Scatter), spin.Scatter, spin.Bonus)
+	}
+	fmt.Printf("total win %.2f\n\n", spin.Win)
+}
+
+// Cluster pays demo game, 7 reels of 7 symbols with cascades.
+func GemClusters() *Slot {
+	W, S, G, R, D, A, K, Q, J := symWild, symScatter, symGold, symRuby, symJade, symAce, symKing, symQueen, symJack
+	return &Slot{
+		Name: "Gem Clusters",
+		Reels: [][]symbol{
+			{A, Q, Q, A, R, K, K, G, R, Q, S, D, A, D, K, J, G, J, R, D, A, D, A, Q, R, J, Q, D, R, G, D, K, A, K, K, R, Q, R, G, K, K, K, Q, W},
+			{J, J, J, J, A, J, W, R, Q, K, A, K, D, J, G, A, Q, J, Q, A, A, Q, K, K, D, S, Q, J, R, J, K, R, Q, J, G, J, J, W, J, Q, A, K, K, D},
+			{D, G, J, A, R, S, R, G, J, J, K, J, A, Q, Q, A, K, A, A, J, J, Q, W, A, Q, G, D, G, J, A, Q, R, D, J, A, R, R, R, D, K, J, D, R, W},
+			{W, Q, K, J, A, K, K, K, D, Q, G, R, K, J, J, Q, A, K, K, Q, J, A, K, Q, J, G, S, Q, J, G, J, Q, J, J, W, R, A, J, A, A, R, R, R, J},
+			{K, J, J, A, K, Q, Q, K, J, R, K, W, Q, K, J, G, K, A, K, R, S, K, A, R, J, D, K, K, A, G, Q, K, D, W, R, J, J, Q, J, J, R, W, J, W},
+			{D, J, A, R, G, D, J, K, A, J, W, J, J, D, J, J, K, K, J, A, K, A, G, A, D, R, J, G, Q, R, J, Q, J, K, K, Q, J, Q, A, A, A, G, Q, K},
+			{D, Q, J, A, A, R, D, W, J, Q, Q, Q, R, R, J, Q, Q, K, A, Q, K, D, Q, D, Q, Q, J, D, Q, A, S, D, A, J, J, G, K, G, R, A, J, J, Q, Q},
+		},
+		Height:  []int{7},
+		Wild:    W,
+		Scatter: S,
+		Cluster: 5,
+		ClusterPays: Paytable{
+			G: {0, 0, 0, 0, 0, 1, 1.5, 2, 3, 5, 8, 12, 20, 30, 50, 100},
+			R: {0, 0, 0, 0, 0, 0.8, 1, 1.5, 2, 3, 5, 8, 12, 20, 30, 60},
+			D: {0, 0, 0, 0, 0, 0.6, 0.8, 1, 1.5, 2, 3, 5, 8, 12, 20, 40},
+			A: {0, 0, 0, 0, 0, 0.4, 0.5, 0.6, 0.8, 1, 1.5, 2, 3, 5, 8, 15},
+			K: {0, 0, 0, 0, 0, 0.3, 0.4, 0.5, 0.6, 0.8, 1, 1.5, 2, 3, 5, 10},
+			Q: {0, 0, 0, 0, 0, 0.2, 0.3, 0.4, 0.5, 0.6, 0.8, 1, 1.5, 2, 3, 6},
+			J: {0, 0, 0, 0, 0, 0.2, 0.2, 0.3, 0.4, 0.5, 0.6, 0.8, 1, 1.5, 2, 5},
+		},
+		Scatters:    []float64{0, 0, 0, 1, 4, 20, 100, 500},
+		Cascade:     true,
+		Multipliers: []float64{1, 2, 4, 8},
+		Names: map[symbol]string{
+			W: "WILD", S: "SCATTER", G: "GOLD", R: "RUBY", D: "JADE",
+			A: "A", K: "K", Q: "Q", J: "J",
+		},
+	}
+}
+
+// Spin and simulate cluster pays demo game.
+func ShowClusters(spins int) {
+	sl := GemClusters()
+	if err := sl.Init(2024); err != nil {
+		fmt.Println(err)
+		return
+	}
+	spin := sl.Spin()
+	spin.PrintSteps(sl)
+	sl.Simulate(spins)
+}
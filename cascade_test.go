@@ -0,0 +1,128 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Clusters share wilds, respect min size and multiply by wild multipliers.
+func TestClusters(t *testing.T) {
+	W, A, K := symWild, symAce, symKing
+	type win struct {
+		s     symbol
+		width int
+		multi int
+		mask  []uint
+	}
+	for _, c := range []struct {
+		name  string
+		grid  [][]symbol
+		min   int
+		wilds []Wild
+		wins  []win
+	}{
+		{"shared wild", [][]symbol{{A, A}, {W, K}, {K, K}}, 3, []Wild{{Symbol: W}},
+			[]win{{A, 3, 1, []uint{3, 1, 0}}, {K, 4, 1, []uint{0, 3, 3}}}},
+		{"min size", [][]symbol{{A, A}, {W, K}, {K, K}}, 4, []Wild{{Symbol: W}},
+			[]win{{K, 4, 1, []uint{0, 3, 3}}}},
+		{"wild multipliers", [][]symbol{{A, W}, {W, A}}, 3, []Wild{{Symbol: W, Multi: 2}},
+			[]win{{A, 4, 4, []uint{3, 3}}}},
+		{"wilds only", [][]symbol{{W, W}, {W, W}}, 3, []Wild{{Symbol: W}}, nil},
+		{"no wild", [][]symbol{{A, A}, {W, K}, {K, K}}, 3, nil,
+			[]win{{K, 3, 1, []uint{0, 2, 3}}}},
+	} {
+		got := Clusters(c.grid, c.min, c.wilds...)
+		if len(got) != len(c.wins) {
+			t.Errorf("%s: %d clusters %+v, want %d", c.name, len(got), got, len(c.wins))
+			continue
+		}
+		for i, w := range c.wins {
+			g := got[i]
+			if g.Symbol != w.s || g.Width != w.width || g.Multi != w.multi || !reflect.DeepEqual(g.Mask, w.mask) {
+				t.Errorf("%s: cluster %d %+v, want %+v", c.name, i, g, w)
+			}
+		}
+	}
+}
+
+// Cascade of fixed stops: refill from strips above stops, progressive
+// multiplier (last repeats), stop without win, scatter paid once on final window.
+func TestTumble(t *testing.T) {
+	S, A, K, Q, J := symScatter, symAce, symKing, symQueen, symJack
+	sl := &Slot{
+		Name: "Tumble",
+		Reels: [][]symbol{
+			{Q, S, A, J, K},
+			{J, Q, A, Q, K},
+			{Q, J, A, J, K},
+		},
+		Height:      []int{3},
+		Wild:        NoSymbol,
+		Scatter:     S,
+		Cluster:     3,
+		ClusterPays: Paytable{A: {0, 0, 0, 10}, K: {0, 0, 0, 4}},
+		Scatters:    []float64{0, 5},
+		Cascade:     true,
+		Multipliers: []float64{1, 2},
+	}
+	if err := sl.Init(1); err != nil {
+		t.Fatal(err)
+	}
+	stops := []int{0, 0, 0}
+	spin := sl.Evaluate(sl.Window(stops))
+	spin.Stops = stops
+	sl.tumble(&spin)
+
+	grids := [][][]symbol{
+		{{Q, S, A}, {J, Q, A}, {Q, J, A}}, // aces on bottom row
+		{{K, Q, S}, {K, J, Q}, {K, Q, J}}, // kings dropped from strip ends
+		{{J, Q, S}, {Q, J, Q}, {J, Q, J}}, // no cluster
+	}
+	if len(spin.Steps) != len(grids) {
+		t.Fatalf("%d steps, want %d", len(spin.Steps), len(grids))
+	}
+	for k, step := range spin.Steps {
+		if !reflect.DeepEqual(step.Grid, grids[k]) {
+			t.Errorf("step %d grid %v, want %v", k, step.Grid, grids[k])
+		}
+		if want := sl.cascadeMulti(k); step.Multi != want {
+			t.Errorf("step %d multiplier %g, want %g", k, step.Multi, want)
+		}
+	}
+	if m := spin.Steps[2].Multi; m != 2 {
+		t.Errorf("last multiplier does not repeat: %g", m)
+	}
+	if len(spin.Wins) != 2 || spin.Wins[0].Symbol != A || spin.Wins[0].Payout != 10 || spin.Wins[1].Symbol != K || spin.Wins[1].Payout != 8 {
+		t.Errorf("wins %+v, want ace 10 and king 4 x2", spin.Wins)
+	}
+	if spin.Scatter != 1 || spin.Bonus != 5 || spin.Win != 10+8+5 {
+		t.Errorf("scatter %d bonus %g win %g, want 1, 5 and 23", spin.Scatter, spin.Bonus, spin.Win)
+	}
+}
+
+// Endless cascade stops after MaxCascades steps.
+func TestTumbleLimit(t *testing.T) {
+	defer func(max int) { MaxCascades = max }(MaxCascades)
+	MaxCascades = 5
+	A := symAce
+	sl := &Slot{
+		Name:        "Endless",
+		Reels:       [][]symbol{{A, A, A}, {A, A, A}},
+		Height:      []int{2},
+		Wild:        NoSymbol,
+		Scatter:     NoSymbol,
+		Cluster:     3,
+		ClusterPays: Paytable{A: {0, 0, 0, 0, 1}},
+		Cascade:     true,
+		Multipliers: []float64{1, 2},
+	}
+	if err := sl.Init(1); err != nil {
+		t.Fatal(err)
+	}
+	spin := sl.Spin()
+	if len(spin.Steps) != MaxCascades || spin.Win != 1+2*float64(MaxCascades-1) {
+		t.Errorf("%d steps win %g, want %d steps win %g", len(spin.Steps), spin.Win, MaxCascades, 1+2*float64(MaxCascades-1))
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"DHSimulator/rng"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+var (
+	CheckpointFile  = ""              // checkpoint file name, no checkpoints if empty
+	CheckpointEvery = 5 * time.Minute // checkpoint interval
+)
+
+// DiamondHunt checkpoint.
+//
+// Everything needed to continue run exactly where it stopped:
+// simulation state, accumulated statistics, generators states and deck order.
+type Checkpoint struct {
+	Hunt    *Hunt                   `json:"hunt"`   // simulation state
+	CatStat map[string]rng.StatCalc `json:"cat"`    // categories statistics
+	CntStat [5]rng.StatCalc         `json:"cnt"`    // diamonds count statistics
+	Dealer  []byte                  `json:"dealer"` // hunt croupier state
+	Cards   []int                   `json:"cards"`  // hunt deck order
+	Mixer   []byte                  `json:"mixer"`  // WSOGMM state
+	Time    time.Time               `json:"time"`   // checkpoint time
+}
+
+// Take snapshot of running simulation.
+func (cp *Checkpoint) Take(hunt *Hunt) (err error) {
+	cp.Hunt, cp.CatStat, cp.CntStat = hunt, CatStat, CntStat
+	cp.Cards = append([]int{}, hunt.deck.Cards...)
+	if cp.Dealer, err = hunt.deck.Croupier.MarshalBinary(); err == nil {
+		cp.Mixer, err = rng.WSOGMM.MarshalBinary()
+	}
+	cp.Time = time.Now()
+	return
+}
+
+// Restore simulation from snapshot.
+func (cp *Checkpoint) Restore() (err error) {
+	if cp.Hunt == nil || len(cp.Cards) != 52 {
+		return fmt.Errorf("checkpoint: incomplete snapshot")
+	}
+	deck := &cp.Hunt.deck
+	deck.Croupier.Solo(true) // deck is used by single goroutine
+	if err = deck.Croupier.UnmarshalBinary(cp.Dealer); err == nil {
+		err = rng.WSOGMM.UnmarshalBinary(cp.Mixer)
+	}
+	if err == nil {
+		deck.Cards = append([]int{}, cp.Cards...)
+		deck.Reset()
+		CatStat, CntStat = cp.CatStat, cp.CntStat
+		if CatStat == nil {
+			CatStat = map[string]rng.StatCalc{}
+		}
+	}
+	return
+}
+
+// Write snapshot to file (atomic rename).
+func (cp *Checkpoint) Write(file string) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	temp := file + ".tmp"
+	if err = os.WriteFile(temp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(temp, file)
+}
+
+// Read snapshot from file.
+func (cp *Checkpoint) Read(file string) error {
+	data, err := os.ReadFile(file)
+	if err == nil {
+		err = json.Unmarshal(data, cp)
+	}
+	return err
+}
+
+// Periodic checkpoint writer.
+type CheckpointTimer struct {
+	File  string        // checkpoint file
+	Every time.Duration // interval
+	last  time.Time     // last checkpoint
+}
+
+// Start timer with global settings.
+func (ct *CheckpointTimer) Start() {
+	ct.File, ct.Every, ct.last = CheckpointFile, CheckpointEvery, time.Now()
+}
+
+// Is checkpoint due before ticket cnt?
+//
+// Clock is consulted every 65536 tickets only.
+func (ct *CheckpointTimer) Due(cnt int) bool {
+	const mask = 1<<16 - 1
+	return ct.File != "" && cnt&mask == 0 && time.Since(ct.last) >= ct.Every
+}
+
+// Save checkpoint, report failure without stopping the run.
+func (ct *CheckpointTimer) Save(hunt *Hunt) {
+	if ct.File == "" {
+		return
+	}
+	var cp Checkpoint
+	err := cp.Take(hunt)
+	if err == nil {
+		err = cp.Write(ct.File)
+	}
+	if err != nil {
+		fmt.Println("checkpoint:", err)
+	}
+	ct.last = time.Now()
+}
+
+// Continue DiamondHunt from checkpoint file, returns hunt and tickets
+// played after resuming.
+//
+// Checkpoints keep going to the same file unless CheckpointFile is set.
+func ResumeHunt(file string) (*Hunt, int, error) {
+	var cp Checkpoint
+	if err := cp.Read(file); err != nil {
+		return nil, 0, err
+	}
+	if err := cp.Restore(); err != nil {
+		return nil, 0, err
+	}
+	if CheckpointFile == "" {
+		CheckpointFile = file
+	}
+	fmt.Printf("resume from %s: %d of %d tickets played\n", cp.Time.Format("2006-01-02 15:04:05"), cp.Hunt.Done, cp.Hunt.Iter)
+	done := cp.Hunt.Done
+	cp.Hunt.Run()
+	return cp.Hunt, cp.Hunt.Done - done, nil
+}
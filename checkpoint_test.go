@@ -0,0 +1,64 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"DHSimulator/rng"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// Hunt resumed from checkpoint continues exactly as uninterrupted run.
+func TestCheckpointResume(t *testing.T) {
+	const tickets = 3000
+	file := filepath.Join(t.TempDir(), "hunt.json")
+	defer func(file string, strategy int) {
+		CheckpointFile, Strategy = file, strategy
+	}(CheckpointFile, Strategy)
+	start := func(iter int) *Hunt {
+		Dealer.Seed(2024)
+		rng.WSOGMM.Randomize(2024)
+		CatStat, CntStat = map[string]rng.StatCalc{}, [5]rng.StatCalc{}
+		hunt := &Hunt{Iter: iter, Strategy: Strategy, Chips: []float64{1, 2, 5}}
+		hunt.Bet.Cat, hunt.Win.Cat = "bet", "win"
+		return hunt
+	}
+
+	CheckpointFile = ""
+	whole := start(tickets)
+	whole.Run()
+	catStat, cntStat := CatStat, CntStat
+
+	CheckpointFile = file // final save of interrupted run
+	half := start(tickets / 2)
+	half.Run()
+	var cp Checkpoint
+	if err := cp.Read(file); err != nil {
+		t.Fatal(err)
+	}
+	cp.Hunt.Iter = tickets // continue to full run
+	if err := cp.Write(file); err != nil {
+		t.Fatal(err)
+	}
+
+	start(0)
+	Dealer.Seed(1) // resume must not depend on current state
+	rng.WSOGMM.Randomize(1)
+	resumed, played, err := ResumeHunt(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if played != tickets-tickets/2 {
+		t.Errorf("resumed run played %d tickets, want %d", played, tickets-tickets/2)
+	}
+	if !reflect.DeepEqual(resumed, whole) {
+		t.Errorf("resumed hunt\n%+v\nuninterrupted hunt\n%+v", resumed, whole)
+	}
+	if !reflect.DeepEqual(CatStat, catStat) {
+		t.Errorf("resumed categories\n%+v\nuninterrupted categories\n%+v", CatStat, catStat)
+	}
+	if CntStat != cntStat {
+		t.Errorf("resumed diamonds counts\n%+v\nuninterrupted diamonds counts\n%+v", CntStat, cntStat)
+	}
+}
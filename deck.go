@@ -84,6 +84,16 @@ func (c *Cards) Value() int {
 	return n
 }
 
+// Pile of cards for BitPoker (card c is bit c - 1).
+func (c *Cards) Pile() (pile bits) {
+	for _, c := range *c {
+		if 1 <= c.Card && c.Card <= 52 {
+			pile |= 1 << (c.Card - 1)
+		}
+	}
+	return
+}
+
 var CardVirtues Cards
 var CardMap map[string]int
 
@@ -119,7 +129,12 @@ type Deck struct {
 
 // Initialize deck of cards.
 func (deck *Deck) Init() {
-	deck.Croupier.Randomize()
+	deck.Seed()
+}
+
+// Initialize deck of cards with seeds (same seeds, same deals) or system state.
+func (deck *Deck) Seed(seeds ...uint64) {
+	deck.Croupier.Randomize(seeds...)
 	deck.Cards = deck.Croupier.Deck()
 	deck.Reset()
 }
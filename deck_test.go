@@ -0,0 +1,23 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import "testing"
+
+// Deal DiamondHunt screen (4 cards and 2 draws) with locked and lock-free croupier.
+func BenchmarkCroupier(b *testing.B) {
+	for _, solo := range []bool{false, true} {
+		name := map[bool]string{false: "locked", true: "solo"}[solo]
+		b.Run(name, func(b *testing.B) {
+			var deck Deck
+			deck.Seed(2024)
+			deck.Croupier.Solo(solo)
+			for i := 0; i < b.N; i++ {
+				deck.Reset()
+				deck.Deal(4)
+				deck.Draw()
+				deck.Draw()
+			}
+		})
+	}
+}
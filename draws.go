@@ -0,0 +1,73 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"fmt"
+)
+
+// # Draw games
+//
+// Balls are drawn from mixer without replacement and ticket wins by its
+// numbers among drawn ones (Keno, Bingo, Tombola, Lucky 6). Probabilities
+// of wins are (multivariate) hypergeometric, simulation of rounds
+// cross-checks them. All pays are in bets.
+
+// Exact probability, pay and return of draw game win category.
+type DrawHit struct {
+	Cat  string  `json:"cat"`  // win category
+	Prob float64 `json:"prob"` // probability of category
+	Pay  float64 `json:"pay"`  // pay of category
+	RTP  float64 `json:"rtp"`  // return of category
+}
+
+// Draw game with exact math and simulated rounds.
+type DrawGame interface {
+	Title() string    // game name
+	Exact() []DrawHit // exact math by win categories
+	Round(st *Tally)  // play round with bet 1 and add wins to tally
+}
+
+// Print exact math and return rtp.
+func PrintHits(title string, hits []DrawHit) (rtp float64) {
+	fmt.Printf("\n%s\n\n", title)
+	fmt.Println("category                     probability           pays         rtp             rate")
+	for _, h := range hits {
+		fmt.Printf("%-26s  %13.9f%%  %13g  %9.5f%%  %15.2f\n", h.Cat, 100*h.Prob, h.Pay, 100*h.RTP, 1/h.Prob)
+		rtp += h.RTP
+	}
+	fmt.Printf("\nrtp %.5f%%\n", 100*rtp)
+	return
+}
+
+// Print exact math of demo Keno, Bingo, Tombola and Lucky 6 games
+// and report of simulated rounds.
+func ShowDraws(rounds int) {
+	keno, bingo, tombola, lucky6 := ClassicKeno(8), ClassicBingo(), ClassicTombola(), ClassicLucky6()
+	for _, c := range []struct {
+		game DrawGame
+		init func(seeds ...uint64) error
+	}{
+		{keno, keno.Init},
+		{bingo, bingo.Init},
+		{tombola, tombola.Init},
+		{lucky6, lucky6.Init},
+	} {
+		if err := c.init(2024); err != nil {
+			fmt.Println(err)
+			return
+		}
+		g := c.game
+		hits := g.Exact()
+		PrintHits(g.Title(), hits)
+		var st Tally
+		for _, h := range hits {
+			st.Order = append(st.Order, h.Cat)
+		}
+		st.Order = append(st.Order, "-", "total")
+		for i := 0; i < rounds; i++ {
+			g.Round(&st)
+		}
+		st.Report(g.Title())
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"DHSimulator/rng"
+	"fmt"
+	"math"
+	"testing"
+)
+
+// Exact math of demo games against independent closed forms
+// (covers rare top prizes which simulation can not reach).
+/*
+	keno:    prob(h) = HYPGEOMDIST(h, 20, spots, 80)
+	lucky 6: prob(n) = C(n - 1, 5) / C(49, 6)
+	bingo:   prob(corners) = HYPGEOMDIST(4, draw, 4, 75)
+	tombola: prob(cinquina) + prob(tombola) = 3·p₅ - 3·p₁₀ + p₁₅, pₖ = HYPGEOMDIST(k, draw, k, 90)
+*/
+func TestDrawsExact(t *testing.T) {
+	type check struct {
+		game, cat string
+		got, want float64
+	}
+	var checks []check
+	for spots := 1; spots <= 10; spots++ {
+		keno := ClassicKeno(spots)
+		for _, h := range keno.Exact() {
+			var hit int
+			fmt.Sscanf(h.Cat, "%d of", &hit)
+			checks = append(checks, check{keno.Title(), h.Cat, h.Prob, rng.HypGeomDist(hit, keno.Draw, spots, KenoBalls)})
+		}
+	}
+	lucky6 := ClassicLucky6()
+	for _, h := range lucky6.Exact() {
+		var n int
+		fmt.Sscanf(h.Cat, "ball %d", &n)
+		checks = append(checks, check{lucky6.Title(), h.Cat, h.Prob, rng.Binomial(n-1, Lucky6Numbers-1) / rng.Binomial(Lucky6Balls, Lucky6Numbers)})
+	}
+	bingo := ClassicBingo()
+	for _, h := range bingo.Exact() {
+		if h.Cat == "corners" {
+			checks = append(checks, check{bingo.Title(), h.Cat, h.Prob, rng.HypGeomDist(4, bingo.Draw, 4, BingoBalls)})
+		}
+	}
+	tombola := ClassicTombola()
+	row, want := 0., 0.
+	for k, sign := 1, 3.; k <= TombolaRows; k++ {
+		want += sign * rng.HypGeomDist(k*TombolaRow, tombola.Draw, k*TombolaRow, TombolaBalls)
+		sign *= -float64(TombolaRows-k) / float64(k+1)
+	}
+	for _, h := range tombola.Exact() {
+		if h.Cat == "cinquina" || h.Cat == "tombola" {
+			row += h.Prob
+		}
+	}
+	checks = append(checks, check{tombola.Title(), "cinquina or tombola", row, want})
+
+	const eps = 1e-12
+	for _, c := range checks {
+		if math.Abs(c.got-c.want) > eps*math.Max(1, c.want) {
+			t.Errorf("%s: %s exact probability %.15g, closed form %.15g", c.game, c.cat, c.got, c.want)
+		}
+	}
+}
+
+// Simulated probability of each category and rtp of demo games within
+// 4 standard errors of exact. Frequencies of categories are binomial,
+// so their check is not loosened by variance of top pays.
+func TestDrawsSimulation(t *testing.T) {
+	const rounds = 200 * 1000
+	keno, bingo, tombola, lucky6 := ClassicKeno(8), ClassicBingo(), ClassicTombola(), ClassicLucky6()
+	for _, c := range []struct {
+		game DrawGame
+		init func(seeds ...uint64) error
+	}{
+		{keno, keno.Init},
+		{bingo, bingo.Init},
+		{tombola, tombola.Init},
+		{lucky6, lucky6.Init},
+	} {
+		if err := c.init(2024); err != nil {
+			t.Fatal(err)
+		}
+		hits, rtp := c.game.Exact(), 0.
+		for _, h := range hits {
+			rtp += h.RTP
+		}
+		var st Tally
+		for i := 0; i < rounds; i++ {
+			c.game.Round(&st)
+		}
+		for _, h := range hits {
+			p := float64(st.Cats[h.Cat].Cnt) / float64(st.Plays)
+			if e := 4 * math.Sqrt(h.Prob*(1-h.Prob)/float64(st.Plays)); math.Abs(p-h.Prob) > e {
+				t.Errorf("%s: %s simulated probability %.7f%%, exact %.7f%% ± %.7f%%", c.game.Title(), h.Cat, 100*p, 100*h.Prob, 100*e)
+			}
+		}
+		if e := 4 * st.Win.Dev / math.Sqrt(float64(st.Plays)); math.Abs(st.RTP()-rtp) > e {
+			t.Errorf("%s: simulated rtp %.5f%%, exact %.5f%% ± %.5f%%", c.game.Title(), 100*st.RTP(), 100*rtp, 100*e)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"DHSimulator/rng"
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// Cards of poker deal and how hand is made of them.
+type DealRule struct {
+	Name string // rule name
+	Hold int    // cards in hold
+	Desk int    // cards on desk
+	Keep int    // cards from hold in hand, -1 for any (best 5 of all)
+}
+
+// Max deals counted serially, more deals are counted on all cores.
+var EnumerateSerial = 100 * 1000
+
+// Poker deals.
+var (
+	FiveCardRule = DealRule{"five card", 5, 0, -1}
+	TexasRule    = DealRule{"Texas Holdem", 2, 5, -1}
+	OmahaRule    = DealRule{"Omaha Holdem", 4, 5, 2}
+)
+
+// Exact hands counts by hand code.
+type Outcome struct {
+	Count [10]int64 `json:"count"` // by hand code
+	Total int64     `json:"total"` // all deals
+}
+
+// Probability of hand code.
+func (o *Outcome) Prob(code int) float64 {
+	if o.Total == 0 || code < 0 || code > 9 {
+		return 0
+	}
+	return float64(o.Count[code]) / float64(o.Total)
+}
+
+// # Enumeration service
+//
+// Counts final hands of all completions of partial hold and desk
+// (every rest of hold, then every rest of desk), in parallel on all cores
+// if there are more than EnumerateSerial deals.
+func (bp *BitPoker) Enumerate(rule DealRule, hold, desk bits) (o Outcome, err error) {
+	bp.ensure()
+	bp.Rank(0) // build tables before sharing
+	switch {
+	case hold&^bp.pack != 0 || desk&^bp.pack != 0:
+		return o, fmt.Errorf("enumerate: cards not in pack")
+	case hold&desk != 0:
+		return o, fmt.Errorf("enumerate: duplicate cards")
+	case bp.Length(hold) > rule.Hold || bp.Length(desk) > rule.Desk:
+		return o, fmt.Errorf("enumerate: %w: too many cards for %s", ErrCardCount, rule.Name)
+	case rule.Hold+rule.Desk < 5 || rule.Hold+rule.Desk > bp.Length(bp.pack):
+		return o, fmt.Errorf("enumerate: bad rule %s", rule.Name)
+	}
+	needHold, needDesk := rule.Hold-bp.Length(hold), rule.Desk-bp.Length(desk)
+	rest := bp.pack &^ (hold | desk)
+
+	code := func(h, d bits) int {
+		if rule.Keep < 0 {
+			return bp.Category(bp.Rank(h | d))
+		}
+		r, _, _ := bp.holdem(h, d, rule.Keep)
+		return bp.Category(r)
+	}
+
+	// work item: rest of hold and lowest card of rest of desk (0 if none)
+	type item struct{ hold, low bits }
+	feed := func(put func(it item)) {
+		var s sampler
+		for s.Init(rest, needHold); !s.Eof(); {
+			h := s.Next()
+			if needDesk == 0 {
+				put(item{h, 0})
+				continue
+			}
+			for p := rest &^ h; p != 0; p &= p - 1 {
+				put(item{h, p & -p})
+			}
+		}
+	}
+	work := func(it item, count *[10]int64, s *sampler) {
+		h := hold | it.hold
+		if it.low == 0 {
+			count[code(h, desk)]++
+			return
+		}
+		above := rest &^ it.hold &^ (it.low<<1 - 1) // cards above lowest
+		for s.Init(above, needDesk-1); !s.Eof(); {
+			count[code(h, desk|it.low|s.Next())]++
+		}
+	}
+	add := func(count *[10]int64) {
+		for c, n := range count {
+			o.Count[c] += n
+			o.Total += n
+		}
+	}
+
+	left := bp.Length(rest)
+	if deals := rng.Binomial(left, needHold) * rng.Binomial(left-needHold, needDesk); deals <= float64(EnumerateSerial) {
+		var count [10]int64 // not worth of goroutines
+		var s sampler
+		feed(func(it item) { work(it, &count, &s) })
+		add(&count)
+		return
+	}
+
+	items := make(chan item, 1024)
+	go func() {
+		feed(func(it item) { items <- it })
+		close(items)
+	}()
+
+	var mx sync.Mutex
+	var wg sync.WaitGroup
+	for w := runtime.NumCPU(); w > 0; w-- {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var count [10]int64
+			var s sampler
+			for it := range items {
+				work(it, &count, &s)
+			}
+			mx.Lock()
+			add(&count)
+			mx.Unlock()
+		}()
+	}
+	wg.Wait()
+	return
+}
+
+// Outcome table in order of hands strength (as in pack comments).
+func (bp *BitPoker) Table(o Outcome) (lines []string) {
+	for p := 9; p >= 0; p-- {
+		h := bp.order[p]
+		lines = append(lines, fmt.Sprintf("%d  %7d  %s", h, o.Count[h], bp.Names[h]))
+	}
+	return append(lines, fmt.Sprintf("Σ  %7d  total", o.Total))
+}
+
+// Print hands tables of Classic, SixUp and SevenUp packs.
+func ShowPackCounts() {
+	for _, game := range []struct {
+		name  string
+		setup func(bp *BitPoker)
+	}{
+		{"Classic", (*BitPoker).Classic},
+		{"SixUp", (*BitPoker).SixUp},
+		{"SevenUp", (*BitPoker).SevenUp},
+	} {
+		var bp BitPoker
+		game.setup(&bp)
+		o, err := bp.Enumerate(FiveCardRule, 0, 0)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Printf("%s\n\tpack = 0x%X\n", game.name, bp.pack)
+		for _, l := range bp.Table(o) {
+			fmt.Println("\t" + l)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import "testing"
+
+// Enumeration service reproduces hands counts of packs (of doc comments).
+func TestPackCounts(t *testing.T) {
+	for _, c := range []struct {
+		name  string
+		setup func(bp *BitPoker)
+		count [10]int64
+	}{
+		{"Classic", (*BitPoker).Classic, [10]int64{1302540, 1098240, 123552, 54912, 10200, 5108, 3744, 624, 36, 4}},
+		{"SixUp", (*BitPoker).SixUp, [10]int64{123420, 193536, 36288, 16128, 5100, 484, 1728, 288, 16, 4}},
+		{"SevenUp", (*BitPoker).SevenUp, [10]int64{53040, 107520, 24192, 10752, 4080, 208, 1344, 224, 12, 4}},
+	} {
+		var bp BitPoker
+		c.setup(&bp)
+		o, err := bp.Enumerate(FiveCardRule, 0, 0)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if o.Count != c.count {
+			t.Errorf("%s: counts %v, want %v", c.name, o.Count, c.count)
+		}
+	}
+}
+
+// Serial and parallel counts agree.
+func TestEnumerateSerial(t *testing.T) {
+	defer func(serial int) { EnumerateSerial = serial }(EnumerateSerial)
+	var bp BitPoker
+	bp.Classic()
+	for _, c := range []struct {
+		rule       DealRule
+		hold, desk string
+	}{
+		{TexasRule, "AsKs", "QsJs2d"},
+		{OmahaRule, "AsKsQhJh", "Td9d"},
+		{FiveCardRule, "AsKs", ""},
+	} {
+		hold, desk := parsePile(t, &bp, c.hold), parsePile(t, &bp, c.desk)
+		EnumerateSerial = 1000 * 1000 * 1000
+		serial, err := bp.Enumerate(c.rule, hold, desk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		EnumerateSerial = 0
+		parallel, err := bp.Enumerate(c.rule, hold, desk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if serial != parallel || serial.Total == 0 {
+			t.Errorf("%s %s %s: serial %v, parallel %v", c.rule.Name, c.hold, c.desk, serial, parallel)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"DHSimulator/rng"
+	"fmt"
+)
+
+var (
+	EquityExact  = 3 * 1000 * 1000 // max deals for exact enumeration
+	EquityTrials = 1000 * 1000     // Monte Carlo deals otherwise
+)
+
+// Showdown chances of player.
+type Equity struct {
+	Win   float64 `json:"win"`   // probability to win alone
+	Tie   float64 `json:"tie"`   // probability to split pot
+	Lose  float64 `json:"lose"`  // probability to lose
+	Share float64 `json:"share"` // expected part of pot (equity)
+}
+
+// Showdown chances of all players.
+type Odds struct {
+	Equity []Equity `json:"equity"` // by player
+	Deals  int      `json:"deals"`  // deals evaluated
+	Exact  bool     `json:"exact"`  // full enumeration?
+}
+
+// # Holdem equity calculator
+//
+// Players hold up to hole cards each (unknown cards are dealt),
+// desk is partial board of up to 5 cards, dead cards are out of play.
+// All deals are enumerated if there are at most EquityExact of them,
+// else EquityTrials random deals are played.
+//
+// Score gives hand rank of hold and full desk (higher is better).
+func (bp *BitPoker) HoldemEquity(players []bits, desk, dead bits, hole int, score func(hold, desk bits) int) (odds Odds, err error) {
+	bp.ensure()
+	bp.Rank(0) // build tables
+
+	// known cards and missing cards by pile (players, then desk)
+	piles := append(append([]bits{}, players...), desk)
+	need := make([]int, len(piles))
+	used := dead & bp.pack
+	for i, p := range piles {
+		size := hole
+		if i == len(players) {
+			size = 5
+		}
+		switch {
+		case p&^bp.pack != 0:
+			return odds, fmt.Errorf("equity: cards %v not in pack", bp.Humanize(p&^bp.pack))
+		case p&used != 0:
+			return odds, fmt.Errorf("equity: duplicate cards %v", bp.Humanize(p&used))
+		case bp.Length(p) > size:
+			return odds, fmt.Errorf("equity: %w: more than %d cards %v", ErrCardCount, size, bp.Humanize(p))
+		}
+		used |= p
+		need[i] = size - bp.Length(p)
+	}
+	rest, deals, left := bp.pack&^used, 1.0, bp.Length(bp.pack&^used)
+	for _, n := range need {
+		if n > left {
+			return odds, fmt.Errorf("equity: %w: not enough cards in pack", ErrCardCount)
+		}
+		deals *= rng.Binomial(left, n)
+		left -= n
+	}
+
+	// showdown of complete deal
+	n := len(players)
+	win, tie, share := make([]float64, n), make([]float64, n), make([]float64, n)
+	ranks := make([]int, n)
+	settle := func() {
+		best, cnt := 0, 0
+		for i, h := range piles[:n] {
+			r := score(h, piles[n])
+			if ranks[i] = r; r > best {
+				best, cnt = r, 1
+			} else if r == best {
+				cnt++
+			}
+		}
+		for i, r := range ranks {
+			if r == best {
+				if cnt == 1 {
+					win[i]++
+				} else {
+					tie[i]++
+				}
+				share[i] += 1 / float64(cnt)
+			}
+		}
+		odds.Deals++
+	}
+
+	if odds.Exact = deals <= float64(EquityExact); odds.Exact {
+		var deal func(i int, rest bits)
+		deal = func(i int, rest bits) {
+			if i == len(piles) {
+				settle()
+				return
+			}
+			var s sampler
+			s.Init(rest, need[i])
+			for !s.Eof() {
+				c := s.Next()
+				piles[i] |= c
+				deal(i+1, rest^c)
+				piles[i] ^= c
+			}
+		}
+		deal(0, rest)
+	} else {
+		var rnd rng.LCPRNG
+		rnd.Solo(true) // own generator, Dealer sequence is untouched
+		rnd.Randomize()
+		cards, known := bp.Inflate(rest), append([]bits{}, piles...)
+		for t := 0; t < EquityTrials; t++ {
+			k := len(cards)
+			for i, m := range need {
+				p := known[i]
+				for ; m > 0; m-- { // partial Knuth shuffle
+					j := rnd.Choice(k)
+					k--
+					cards[j], cards[k] = cards[k], cards[j]
+					p |= 1 << cards[k]
+				}
+				piles[i] = p
+			}
+			settle()
+		}
+	}
+
+	odds.Equity = make([]Equity, n)
+	for i := range odds.Equity {
+		d := float64(odds.Deals)
+		odds.Equity[i] = Equity{win[i] / d, tie[i] / d, 1 - (win[i]+tie[i])/d, share[i] / d}
+	}
+	return
+}
+
+// Texas Holdem equity of players with 2 hole cards.
+func (bp *BitPoker) TexasEquity(players []bits, desk, dead bits) (Odds, error) {
+	return bp.HoldemEquity(players, desk, dead, 2, func(hold, desk bits) int {
+		return bp.Rank(hold | desk)
+	})
+}
+
+// Omaha Holdem equity of players with 4 hole cards (2 from hold and 3 from desk).
+func (bp *BitPoker) OmahaEquity(players []bits, desk, dead bits) (Odds, error) {
+	return bp.HoldemEquity(players, desk, dead, 4, func(hold, desk bits) int {
+		r, _, _ := bp.holdem(hold, desk, 2) // as OmahaHoldem
+		return r
+	})
+}
+
+// Texas Holdem equity of players with engine cards.
+func (pok *Poker) TexasEquity(players [][]int, desk, dead []int) (Odds, error) {
+	return pok.bp.TexasEquity(pok.piles(players), pok.bp.Squeeze(desk...), pok.bp.Squeeze(dead...))
+}
+
+// Omaha Holdem equity of players with engine cards.
+func (pok *Poker) OmahaEquity(players [][]int, desk, dead []int) (Odds, error) {
+	return pok.bp.OmahaEquity(pok.piles(players), pok.bp.Squeeze(desk...), pok.bp.Squeeze(dead...))
+}
+
+// Piles of players engine cards.
+func (pok *Poker) piles(players [][]int) []bits {
+	p := make([]bits, len(players))
+	for i, hold := range players {
+		p[i] = pok.bp.Squeeze(hold...)
+	}
+	return p
+}
+
+// Print odds table.
+func (odds Odds) Print(names ...string) {
+	how := "Monte Carlo"
+	if odds.Exact {
+		how = "exact"
+	}
+	fmt.Printf("%d deals (%s)\n", odds.Deals, how)
+	for i, e := range odds.Equity {
+		name := fmt.Sprintf("player %d", i+1)
+		if i < len(names) {
+			name = names[i]
+		}
+		fmt.Printf("%-16s  win %8.4f%%  tie %8.4f%%  lose %8.4f%%  equity %8.4f%%\n", name, 100*e.Win, 100*e.Tie, 100*e.Lose, 100*e.Share)
+	}
+	fmt.Println()
+}
+
+// Few well known showdowns.
+func ShowEquity() {
+	var bp BitPoker
+	bp.Classic()
+	pile := func(cards ...int) bits { // bit indices as 4 * kind + suit
+		return bp.Deflate(cards)
+	}
+	const s, d, h, c = 0, 1, 2, 3
+	A, K, Q, J, T := 4*12, 4*11, 4*10, 4*9, 4*8
+
+	// AA vs KK preflop: 81.06% vs 18.55% win, 0.38% tie
+	if odds, err := bp.TexasEquity([]bits{pile(A+s, A+h), pile(K+d, K+c)}, 0, 0); err == nil {
+		odds.Print("A♠ A♥", "K♦ K♣")
+	}
+	// AK vs QQ vs unknown hand on J T 2 flop
+	if odds, err := bp.TexasEquity([]bits{pile(A+s, K+s), pile(Q+d, Q+h), 0}, pile(J+s, T+s, 0+d), 0); err == nil {
+		odds.Print("A♠ K♠", "Q♦ Q♥", "? ?")
+	}
+	// Omaha AAKK double suited vs random hand
+	if odds, err := bp.OmahaEquity([]bits{pile(A+s, A+h, K+s, K+h), 0}, 0, 0); err == nil {
+		odds.Print("A♠ A♥ K♠ K♥", "? ? ? ?")
+	}
+}
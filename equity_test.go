@@ -0,0 +1,99 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"math"
+	"testing"
+)
+
+// Parse cards or stop test.
+func parsePile(t *testing.T, bp *BitPoker, cards string) bits {
+	t.Helper()
+	pile, err := bp.Parse(cards)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pile
+}
+
+// Exact AA vs KK preflop by suits overlap, known 81.95% vs 18.05% on average.
+func TestTexasEquityExact(t *testing.T) {
+	var bp BitPoker
+	bp.Classic()
+	aces := parsePile(t, &bp, "AsAh")
+	share := 0.
+	for _, c := range []struct {
+		kings  string
+		combos int     // of 6 kings combos
+		win    float64 // aces win alone
+	}{
+		{"KdKc", 1, 0.8106}, // no suit shared
+		{"KsKd", 4, 0.8171}, // one suit shared
+		{"KsKh", 1, 0.8236}, // both suits shared
+	} {
+		odds, err := bp.TexasEquity([]bits{aces, parsePile(t, &bp, c.kings)}, 0, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !odds.Exact || odds.Deals != 1712304 { // C(48, 5)
+			t.Fatalf("AsAh vs %s: %d deals (exact %v), want 1712304 exact", c.kings, odds.Deals, odds.Exact)
+		}
+		a, k := odds.Equity[0], odds.Equity[1]
+		if math.Abs(a.Win-c.win) > 1e-4 {
+			t.Errorf("AsAh vs %s: aces win %.7f, want %.7f", c.kings, a.Win, c.win)
+		}
+		if a.Win != k.Lose || a.Tie != k.Tie || math.Abs(a.Share+k.Share-1) > 1e-12 {
+			t.Errorf("AsAh vs %s: inconsistent odds %+v", c.kings, odds.Equity)
+		}
+		share += float64(c.combos) * a.Share / 6
+	}
+	if math.Abs(share-0.8195) > 5e-4 {
+		t.Errorf("AA vs KK: aces equity %.5f, want 0.8195", share)
+	}
+}
+
+// Monte Carlo agrees with exact enumeration within 4 standard errors.
+func TestTexasEquityMonteCarlo(t *testing.T) {
+	defer func(exact, trials int) {
+		EquityExact, EquityTrials = exact, trials
+	}(EquityExact, EquityTrials)
+	var bp BitPoker
+	bp.Classic()
+	players := []bits{parsePile(t, &bp, "AsKs"), parsePile(t, &bp, "QdQh"), 0}
+	desk := parsePile(t, &bp, "JsTs2d")
+	exact, err := bp.TexasEquity(players, desk, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	EquityExact, EquityTrials = 0, 200*1000
+	mc, err := bp.TexasEquity(players, desk, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !exact.Exact || mc.Exact || mc.Deals != EquityTrials {
+		t.Fatalf("exact %v with %d deals, Monte Carlo %v with %d deals", exact.Exact, exact.Deals, mc.Exact, mc.Deals)
+	}
+	for i, e := range exact.Equity {
+		p := e.Share
+		if d := 4 * math.Sqrt(p*(1-p)/float64(mc.Deals)); math.Abs(mc.Equity[i].Share-p) > d {
+			t.Errorf("player %d: Monte Carlo equity %.5f, exact %.5f ± %.5f", i+1, mc.Equity[i].Share, p, d)
+		}
+	}
+}
+
+// Cards used twice or outside of pack are refused.
+func TestTexasEquityErrors(t *testing.T) {
+	var bp BitPoker
+	bp.Classic()
+	aces := parsePile(t, &bp, "AsAh")
+	if _, err := bp.TexasEquity([]bits{aces, parsePile(t, &bp, "AsKd")}, 0, 0); err == nil {
+		t.Error("duplicate hole card accepted")
+	}
+	if _, err := bp.TexasEquity([]bits{aces, 0}, 0, parsePile(t, &bp, "Ah")); err == nil {
+		t.Error("dead hole card accepted")
+	}
+	if _, err := bp.TexasEquity([]bits{aces | parsePile(t, &bp, "Kd"), 0}, 0, 0); err == nil {
+		t.Error("three hole cards accepted")
+	}
+}
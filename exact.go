@@ -0,0 +1,283 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Exact hits of symbol on count consecutive reels.
+//
+// Ways are multiplied by wilds. For paylines hits count winning lines
+// and ways multiplied winning lines.
+type WaysHit struct {
+	Symbol symbol  `json:"s"`   // symbol
+	Order  int     `json:"o"`   // 1 from left, -1 from right
+	Count  int     `json:"n"`   // consecutive reels
+	Hits   int64   `json:"h"`   // stops combinations with win
+	Ways   int64   `json:"w"`   // multiplied ways in all combinations
+	Line   float64 `json:"l"`   // single way win
+	RTP    float64 `json:"rtp"` // return of hits
+}
+
+// Exact return of slot game.
+type SlotMath struct {
+	Combos  int64     `json:"combos"`  // all stops combinations
+	Hits    []WaysHit `json:"hits"`    // ways hits by symbol and count
+	Paid    []WaysHit `json:"paid"`    // paylines hits by symbol and count
+	Scatter []int64   `json:"scatter"` // combinations by scatters count
+	Ways    float64   `json:"ways"`    // return of ways
+	Lines   float64   `json:"lines"`   // return of paylines
+	Bonus   float64   `json:"bonus"`   // return of scatters
+	RTP     float64   `json:"rtp"`     // total return
+}
+
+// Reel windows statistics of symbol (or wild) over all stops.
+type reelCounts struct {
+	sum  int64 // Σ count (multiplied by wilds)
+	some int64 // stops with count > 0
+	none int64 // stops with count = 0
+}
+
+// # Exact RTP of ways and paylines game
+//
+// Reels are independent, so expected ways of symbol on exactly n reels is
+// product of window counts sums on first n reels, times stops without
+// symbol on reel n + 1, times stops of other reels. Wild only ways are
+// taken off as in Ways:
+/*
+	ways(s, n) = (Π Σc(s) - Π Σc(w)) · Z(s, n+1) · Π len
+	hits(s, n) = (Π P(s) - Π P(w = s)) · Z(s, n+1) · Π len
+*/
+// where c(s) counts symbol or wilds in window (wilds by multipliers), c(w)
+// wilds only, P counts stops with c > 0 and P(w = s) stops where all of c
+// are wilds. Expanding wilds are expanded in windows, sticky are ignored.
+// Cluster pays and cascades are not covered, error for them (simulate them).
+func (sl *Slot) Exact() (sm SlotMath, err error) {
+	if sl.Cluster > 0 || sl.Cascade {
+		return sm, fmt.Errorf("slot %s: no exact math for cluster pays or cascades", sl.Name)
+	}
+	n := len(sl.Reels)
+	sm.Combos = 1
+	for _, r := range sl.Reels {
+		sm.Combos *= int64(len(r))
+	}
+
+	// windows of all stops of reel (expanded)
+	windows := make([][][]symbol, n)
+	for i, r := range sl.Reels {
+		h := sl.height(i)
+		for s := range r {
+			var w []symbol
+			for j := 0; j < h; j++ {
+				w = append(w, r[(s+j)%len(r)])
+			}
+			windows[i] = append(windows[i], sl.expand([][]symbol{w})[0])
+		}
+	}
+
+	// counts of symbol with wilds (and wilds only) by reel, sums multiplied
+	counts := func(s symbol) (all, wild []reelCounts) {
+		all, wild = make([]reelCounts, n), make([]reelCounts, n)
+		for i, ws := range windows {
+			for _, w := range ws {
+				c, d, cm, dm := 0, 0, 0, 0
+				for _, x := range w {
+					if m := sl.multi(x); m > 0 {
+						c, d, cm, dm = c+1, d+1, cm+m, dm+m
+					} else if x == s {
+						c, cm = c+1, cm+1
+					}
+				}
+				all[i].add(c, cm)
+				if wild[i].sum += int64(dm); c > 0 && c == d {
+					wild[i].some++
+				}
+			}
+		}
+		return
+	}
+
+	primary := NoSymbol // wilds only pay as first wild
+	if wilds := sl.wilds(); len(wilds) > 0 {
+		primary = wilds[0].Symbol
+	}
+	var symbols []symbol
+	for s := range sl.Pays {
+		if s != sl.Scatter && (s == primary || sl.multi(s) == 0) {
+			symbols = append(symbols, s)
+		}
+	}
+	sort.Ints(symbols)
+	for _, order := range sl.orders() {
+		for _, s := range symbols {
+			all, wild := counts(s)
+			if s == primary { // wild pays its own ways only
+				wild = make([]reelCounts, n)
+			}
+			ways, hits := int64(1), int64(1) // first reels products
+			wways, whits := int64(1), int64(1)
+			for k := 1; k <= n; k++ {
+				i := reelOf(k-1, n, order)
+				ways *= all[i].sum
+				hits *= all[i].some
+				wways *= wild[i].sum
+				whits *= wild[i].some
+				rest := sm.Combos // other reels
+				for j := 0; j < k; j++ {
+					rest /= int64(len(sl.Reels[reelOf(j, n, order)]))
+				}
+				if k < n {
+					next := reelOf(k, n, order)
+					rest = rest / int64(len(sl.Reels[next])) * all[next].none
+				}
+				line := sl.Line(s, k)
+				if line <= 0 || ways == 0 || (sl.Order == 0 && order < 0 && k == n) {
+					continue
+				}
+				h := WaysHit{Symbol: s, Order: order, Count: k, Hits: (hits - whits) * rest, Ways: (ways - wways) * rest, Line: line}
+				h.RTP = line * float64(h.Ways) / float64(sm.Combos)
+				sm.Hits = append(sm.Hits, h)
+				sm.Ways += h.RTP
+			}
+		}
+	}
+
+	// paylines: symbols sequences of line weighted by product of counts
+	// of each symbol on line row of reel windows
+	if len(sl.Lines) > 0 {
+		type key struct {
+			s            symbol
+			order, count int
+		}
+		hits, paid := map[key]int64{}, map[key]int64{}
+		seq, rev := make([]symbol, n), make([]symbol, n)
+		freq := make([]map[symbol]int64, n)
+		var deal func(i int, weight int64)
+		deal = func(i int, weight int64) {
+			if i == n {
+				for _, order := range sl.orders() {
+					line := seq
+					if order < 0 {
+						for j, x := range seq {
+							rev[n-1-j] = x
+						}
+						line = rev
+					}
+					if s, count, pay, multi := sl.lineWin(line); pay > 0 && !(sl.Order == 0 && order < 0 && count == n) {
+						hits[key{s, order, count}] += weight
+						paid[key{s, order, count}] += weight * int64(multi)
+					}
+				}
+				return
+			}
+			for x, f := range freq[i] {
+				seq[i] = x
+				deal(i+1, weight*f)
+			}
+		}
+		for _, rows := range sl.Lines {
+			for i, ws := range windows {
+				freq[i] = map[symbol]int64{}
+				for _, w := range ws {
+					freq[i][w[rows[i]]]++
+				}
+			}
+			deal(0, 1)
+		}
+		for k, c := range hits {
+			h := WaysHit{Symbol: k.s, Order: k.order, Count: k.count, Hits: c, Ways: paid[k], Line: sl.LinePay(k.s, k.count)}
+			h.RTP = h.Line * float64(h.Ways) / float64(sm.Combos)
+			sm.Paid = append(sm.Paid, h)
+			sm.Lines += h.RTP
+		}
+		sort.Slice(sm.Paid, func(a, b int) bool {
+			pa, pb := sm.Paid[a], sm.Paid[b]
+			if pa.Symbol != pb.Symbol {
+				return pa.Symbol < pb.Symbol
+			}
+			if pa.Order != pb.Order {
+				return pa.Order > pb.Order
+			}
+			return pa.Count < pb.Count
+		})
+	}
+
+	// scatters count distribution, convolution of reels
+	if sl.Scatter != NoSymbol {
+		sm.Scatter = []int64{1}
+		for _, ws := range windows {
+			var reel []int64
+			for _, w := range ws {
+				c := 0
+				for _, x := range w {
+					if x == sl.Scatter {
+						c++
+					}
+				}
+				for len(reel) <= c {
+					reel = append(reel, 0)
+				}
+				reel[c]++
+			}
+			next := make([]int64, len(sm.Scatter)+len(reel)-1)
+			for a, p := range sm.Scatter {
+				for b, q := range reel {
+					next[a+b] += p * q
+				}
+			}
+			sm.Scatter = next
+		}
+		for c, cnt := range sm.Scatter {
+			sm.Bonus += sl.ScatterPay(c) * float64(cnt) / float64(sm.Combos)
+		}
+	}
+	sm.RTP = sm.Ways + sm.Lines + sm.Bonus
+	return
+}
+
+// Add window count and multiplied count.
+func (rc *reelCounts) add(c, cm int) {
+	rc.sum += int64(cm)
+	if c > 0 {
+		rc.some++
+	} else {
+		rc.none++
+	}
+}
+
+// Category of exact hit (as in simulation report).
+func (sl *Slot) hitCategory(h WaysHit, payline bool) string {
+	w := WaysItemNew{Symbol: h.Symbol, Width: h.Count}
+	if payline {
+		w.Payline = 1
+	}
+	return sl.winCategory(w)
+}
+
+// Print exact hits table.
+func (sm *SlotMath) Print(sl *Slot) {
+	fmt.Printf("\n%s,  %d combinations\n\n", sl.Name, sm.Combos)
+	fmt.Println("category                          hits              ways     probability         rtp             rate")
+	for _, part := range []struct {
+		hits    []WaysHit
+		payline bool
+	}{{sm.Hits, false}, {sm.Paid, true}} {
+		for _, h := range part.hits {
+			cat := sl.hitCategory(h, part.payline)
+			if h.Order < 0 {
+				cat += " ←"
+			}
+			prob := float64(h.Hits) / float64(sm.Combos)
+			fmt.Printf("%-26s  %12d  %16d  %13.9f%%  %9.5f%%  %15.2f\n", cat, h.Hits, h.Ways, 100*prob, 100*h.RTP, 1/prob)
+		}
+	}
+	for c := len(sm.Scatter) - 1; c > 0; c-- {
+		if pay := sl.ScatterPay(c); pay > 0 {
+			prob := float64(sm.Scatter[c]) / float64(sm.Combos)
+			fmt.Printf("%-26s  %12d  %16s  %13.9f%%  %9.5f%%  %15.2f\n", sl.category(sl.Scatter, c), sm.Scatter[c], "", 100*prob, 100*pay*prob, 1/prob)
+		}
+	}
+	fmt.Printf("\nways %.5f%%,  lines %.5f%%,  scatter %.5f%%,  rtp %.5f%%\n\n", 100*sm.Ways, 100*sm.Lines, 100*sm.Bonus, 100*sm.RTP)
+}
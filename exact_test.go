@@ -0,0 +1,123 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"math"
+	"testing"
+)
+
+// Small 4 reels game (ways from left), with paylines both ways and with
+// multiplier and expanding wild variants.
+func smallWays(variant string) *Slot {
+	W, S, A, K, Q := symWild, symScatter, symAce, symKing, symQueen
+	sl := &Slot{
+		Name: "small ways",
+		Reels: [][]symbol{
+			{A, K, W, Q, S, A, Q},
+			{K, W, A, Q, K, S},
+			{Q, A, W, W, K, A, S, Q},
+			{A, S, K, Q, W},
+		},
+		Height:   []int{2, 3},
+		Wild:     W,
+		Scatter:  S,
+		Order:    1,
+		Pays:     Paytable{W: {0, 0, 0.05, 0.25, 1}, A: {0, 0, 0.025, 0.1, 0.4}, K: {0, 0, 0.02, 0.075, 0.3}, Q: {0, 0, 0.01, 0.05, 0.2}},
+		Scatters: []float64{0, 0, 0.05, 0.25, 1.25},
+	}
+	if variant == "ways" {
+		return sl
+	}
+	sl.Name, sl.Order = "small both ways", 0
+	sl.Lines = [][]int{{0, 0, 0, 0}, {1, 1, 1, 1}, {0, 1, 1, 0}, {1, 2, 0, 2}}
+	sl.LinePays = Paytable{W: {0, 0, 0.1, 0.5, 2}, A: {0, 0, 0.05, 0.2, 1}, K: {0, 0, 0.04, 0.15, 0.6}, Q: {0, 0, 0.02, 0.1, 0.4}}
+	if variant == "both ways" {
+		return sl
+	}
+	X, E := symbol(10), symbol(11) // multiplier and expanding wilds
+	sl.Name = "small wild variants"
+	sl.Reels[1] = append(sl.Reels[1], X, A, E)
+	sl.Reels[2] = append(sl.Reels[2], X, K)
+	sl.Reels[3] = append(sl.Reels[3], E, Q, X)
+	sl.Wilds = []Wild{{Symbol: X, Multi: 2}, {Symbol: E, Multi: 3, Expand: true}}
+	return sl
+}
+
+// Exact math against evaluation of every stops combination.
+func TestExactEnumeration(t *testing.T) {
+	for _, variant := range []string{"ways", "both ways", "wild variants"} {
+		sl := smallWays(variant)
+		sm, err := sl.Exact()
+		if err != nil {
+			t.Fatalf("%s: %v", variant, err)
+		}
+		ways, scatter := map[string]int64{}, make([]int64, len(sm.Scatter))
+		win := 0.
+		stops := make([]int, len(sl.Reels))
+		for more := true; more; {
+			spin := sl.Evaluate(sl.Window(stops))
+			for _, w := range spin.Wins {
+				ways[sl.winCategory(w)] += int64(w.Multi)
+			}
+			if len(scatter) > 0 {
+				scatter[spin.Scatter]++
+			}
+			win += spin.Win
+			more = false
+			for i := range stops { // next combination
+				if stops[i]++; stops[i] < len(sl.Reels[i]) {
+					more = true
+					break
+				}
+				stops[i] = 0
+			}
+		}
+		exact := map[string]int64{}
+		for _, h := range sm.Hits {
+			exact[sl.hitCategory(h, false)] += h.Ways
+		}
+		for _, h := range sm.Paid {
+			exact[sl.hitCategory(h, true)] += h.Ways
+		}
+		for cat, n := range ways {
+			if exact[cat] != n {
+				t.Errorf("%s: %s ways %d, enumerated %d", variant, cat, exact[cat], n)
+			}
+			delete(exact, cat)
+		}
+		for cat, n := range exact {
+			if n != 0 {
+				t.Errorf("%s: %s ways %d never enumerated", variant, cat, n)
+			}
+		}
+		for c, n := range sm.Scatter {
+			if scatter[c] != n {
+				t.Errorf("%s: %d scatters %d, enumerated %d", variant, c, n, scatter[c])
+			}
+		}
+		if rtp := win / float64(sm.Combos); math.Abs(rtp-sm.RTP) > 1e-12 {
+			t.Errorf("%s: rtp %.9f, enumerated %.9f", variant, sm.RTP, rtp)
+		}
+	}
+}
+
+// Simulated rtp of demo games within 4 standard errors of exact.
+func TestExactSimulation(t *testing.T) {
+	for _, sl := range []*Slot{GoldenWays(), GoldenLines()} {
+		if err := sl.Init(2024); err != nil {
+			t.Fatal(err)
+		}
+		sm, err := sl.Exact()
+		if err != nil {
+			t.Fatalf("%s: %v", sl.Name, err)
+		}
+		for i := 0; i < 200*1000; i++ {
+			sl.Stats.Play(1, sl.Play().Win)
+		}
+		st := &sl.Stats
+		if e := 4 * st.Win.Dev / math.Sqrt(float64(st.Plays)); math.Abs(st.RTP()-sm.RTP) > e {
+			t.Errorf("%s: simulated rtp %.5f%%, exact %.5f%% ± %.5f%%", sl.Name, 100*st.RTP(), 100*sm.RTP, 100*e)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"fmt"
+)
+
+// # Slot feature
+//
+// Feature qualifies when base spin shows at least Min scatters. Chance is
+// not independent trigger percent: some qualified feature triggers with
+// probability 1 - Π (1 - Chance / 100) and exactly one of them is chosen
+// in proportion to Chance (LCPRNG.TriggerIndex), so qualified features of
+// chances 100 and 30 trigger in 76.9% and 23.1% of spins.
+//
+// Free spins feature awards Spins by scatters count (on its own reels if
+// set), wins are multiplied by Multi and scatters in free spins add
+// Retrigger spins (Spins if not set), up to Limit spins.
+//
+// Pick bonus feature gives Picks by scatters count, each pick draws one
+// of Prizes (in total bets) by Weights.
+type Feature struct {
+	Name      string     `json:"name"`                // feature name
+	Min       int        `json:"min"`                 // min scatters to qualify
+	Chance    int        `json:"chance"`              // trigger weight (percent) of qualified feature
+	Spins     []int      `json:"spins,omitempty"`     // free spins by scatters count
+	Retrigger []int      `json:"retrigger,omitempty"` // added free spins by scatters count
+	Multi     float64    `json:"multi,omitempty"`     // free spins win multiplier (1 if 0)
+	Reels     [][]symbol `json:"reels,omitempty"`     // free spins reel strips
+	Sticky    bool       `json:"sticky,omitempty"`    // hold sticky wilds over free spins
+	Limit     int        `json:"limit,omitempty"`     // max free spins (0 no limit)
+	Picks     []int      `json:"picks,omitempty"`     // bonus picks by scatters count
+	Prizes    []float64  `json:"prizes,omitempty"`    // pick prizes
+	Weights   []int      `json:"weights,omitempty"`   // pick prizes weights
+}
+
+// Result of game round: base spin and triggered feature.
+type Round struct {
+	Spin    Spin    `json:"spin"`              // base game spin
+	Feature string  `json:"feature,omitempty"` // triggered feature
+	Free    []Spin  `json:"free,omitempty"`    // free spins
+	Picks   []int   `json:"picks,omitempty"`   // picked prizes
+	Bonus   float64 `json:"bonus"`             // feature win
+	Win     float64 `json:"win"`               // total win
+}
+
+// Count of table by scatters count (last repeats).
+func byScatters(table []int, scatters int) int {
+	if len(table) == 0 {
+		return 0
+	}
+	if scatters >= len(table) {
+		scatters = len(table) - 1
+	}
+	return table[scatters]
+}
+
+// Feature triggered by scatters, nil if none.
+func (sl *Slot) trigger(scatters int) *Feature {
+	chances := make([]int, len(sl.Features))
+	for i, f := range sl.Features {
+		if scatters >= f.Min && f.Min > 0 {
+			chances[i] = f.Chance
+		}
+	}
+	if i := sl.rnd.TriggerIndex(chances...); i >= 0 {
+		return &sl.Features[i]
+	}
+	return nil
+}
+
+// Play free spins (with retriggers).
+func (sl *Slot) freeSpins(f *Feature, spins int, round *Round) {
+	base := sl.Reels
+	if f.Reels != nil {
+		sl.Reels = f.Reels
+	}
+	sl.Hold(f.Sticky)
+	defer func() {
+		sl.Reels = base
+		sl.Hold(false)
+	}()
+	multi := f.Multi
+	if multi == 0 {
+		multi = 1
+	}
+	retrigger := f.Retrigger
+	if retrigger == nil {
+		retrigger = f.Spins
+	}
+	for played := 0; played < spins; played++ {
+		spin := sl.Spin()
+		spin.Win *= multi
+		round.Free = append(round.Free, spin)
+		round.Bonus += spin.Win
+		if spin.Scatter >= f.Min {
+			if spins += byScatters(retrigger, spin.Scatter); f.Limit > 0 && spins > f.Limit {
+				spins = f.Limit
+			}
+		}
+	}
+}
+
+// Play pick bonus.
+func (sl *Slot) pickBonus(f *Feature, picks int, round *Round) {
+	for ; picks > 0; picks-- {
+		p := sl.rnd.Weighted(f.Weights)
+		if p < 0 || p >= len(f.Prizes) {
+			continue
+		}
+		round.Picks = append(round.Picks, p)
+		round.Bonus += f.Prizes[p]
+	}
+}
+
+// Play game round: base spin and feature it triggers.
+func (sl *Slot) Play() (round Round) {
+	round.Spin = sl.Spin()
+	if f := sl.trigger(round.Spin.Scatter); f != nil {
+		round.Feature = f.Name
+		if spins := byScatters(f.Spins, round.Spin.Scatter); spins > 0 {
+			sl.freeSpins(f, spins, &round)
+		}
+		if picks := byScatters(f.Picks, round.Spin.Scatter); picks > 0 {
+			sl.pickBonus(f, picks, &round)
+		}
+	}
+	round.Win = round.Spin.Win + round.Bonus
+	return
+}
+
+// Expected prize of single pick.
+func (f *Feature) PickValue() float64 {
+	sum, value := 0, 0.
+	for i, w := range f.Weights {
+		if i < len(f.Prizes) && w > 0 {
+			sum += w
+			value += float64(w) * f.Prizes[i]
+		}
+	}
+	if sum == 0 {
+		return 0
+	}
+	return value / float64(sum)
+}
+
+// Print round with feature.
+func (round *Round) Print(sl *Slot) {
+	round.Spin.Print(sl)
+	if round.Feature == "" {
+		return
+	}
+	fmt.Printf("%s\n", round.Feature)
+	for i, spin := range round.Free {
+		fmt.Printf("free spin %d  scatters %d  win %.2f\n", i+1, spin.Scatter, spin.Win)
+	}
+	for _, f := range sl.Features {
+		if f.Name != round.Feature {
+			continue
+		}
+		for _, p := range round.Picks {
+			fmt.Printf("pick  %.2f\n", f.Prizes[p])
+		}
+	}
+	fmt.Printf("feature win %.2f,  total win %.2f\n\n", round.Bonus, round.Win)
+}
+
+// Demo game with reels of Golden Ways, free spins and pick bonus.
+func GoldenFeatures() *Slot {
+	sl := GoldenWays()
+	sl.Name = "Golden Features"
+	sl.Scatters = []float64{0, 0, 0, 1, 5, 20}
+	sl.Features = []Feature{
+		{Name: "free spins", Min: 3, Chance: 100, Spins: []int{0, 0, 0, 6, 10, 20}, Multi: 1},
+		{Name: "pick bonus", Min: 3, Chance: 30, Picks: []int{0, 0, 0, 3, 4, 5},
+			Prizes: []float64{1, 2, 5, 10, 50}, Weights: []int{50, 30, 15, 4, 1}},
+	}
+	return sl
+}
+
+// Play and simulate features demo game.
+func ShowFeatures(rounds int) {
+	sl := GoldenFeatures()
+	if err := sl.Init(2024); err != nil {
+		fmt.Println(err)
+		return
+	}
+	for {
+		if round := sl.Play(); round.Feature != "" {
+			round.Print(sl)
+			break
+		}
+	}
+	sl.Simulate(rounds)
+}
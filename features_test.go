@@ -0,0 +1,124 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import "testing"
+
+// Pick bonus without matching weights is refused.
+func TestFeaturePickWeights(t *testing.T) {
+	for _, c := range []struct {
+		name    string
+		weights []int
+		ok      bool
+	}{
+		{"matching", []int{50, 30, 15, 4, 1}, true},
+		{"empty", nil, false},
+		{"short", []int{50, 30}, false},
+		{"zero", []int{0, 0, 0, 0, 0}, false},
+		{"negative", []int{50, 30, 15, 4, -1}, false},
+	} {
+		sl := GoldenFeatures()
+		sl.Features[1].Weights = c.weights
+		if err := sl.Init(2024); (err == nil) != c.ok {
+			t.Errorf("%s weights: Init error %v", c.name, err)
+		}
+	}
+}
+
+// Game with reels always showing 3 scatters (scatter pays 1) and given features.
+func scatterSlot(features ...Feature) *Slot {
+	S := symScatter
+	return &Slot{
+		Name:     "Scatters",
+		Reels:    [][]symbol{{S}, {S}, {S}},
+		Height:   []int{1},
+		Wild:     NoSymbol,
+		Scatter:  S,
+		Scatters: []float64{0, 0, 0, 1},
+		Features: features,
+	}
+}
+
+// Free spins awards, retriggers up to Limit, multiplier and own reels.
+func TestFreeSpins(t *testing.T) {
+	A := symAce
+	for _, c := range []struct {
+		name  string
+		f     Feature
+		spins int
+		bonus float64
+	}{
+		{"award", Feature{Spins: []int{0, 0, 0, 5}, Retrigger: []int{0}, Multi: 2}, 5, 10},
+		{"default multiplier", Feature{Spins: []int{0, 0, 0, 5}, Retrigger: []int{0}}, 5, 5},
+		{"retrigger limit", Feature{Spins: []int{0, 0, 0, 5}, Retrigger: []int{0, 0, 0, 2}, Limit: 11, Multi: 2}, 11, 22},
+		{"spins retrigger limit", Feature{Spins: []int{0, 0, 0, 5}, Limit: 12}, 12, 12},
+		{"own reels", Feature{Spins: []int{0, 0, 0, 5}, Reels: [][]symbol{{A}, {A}, {A}}}, 5, 0},
+	} {
+		c.f.Name, c.f.Min, c.f.Chance = "free spins", 3, 100
+		sl := scatterSlot(c.f)
+		if err := sl.Init(2024); err != nil {
+			t.Fatal(err)
+		}
+		round := sl.Play()
+		if round.Feature != c.f.Name || len(round.Free) != c.spins || round.Bonus != c.bonus || round.Win != 1+c.bonus {
+			t.Errorf("%s: feature %q, %d free spins, bonus %g, win %g, want %d spins, bonus %g", c.name, round.Feature, len(round.Free), round.Bonus, round.Win, c.spins, c.bonus)
+		}
+		if sl.Reels[0][0] != symScatter || sl.sticky != nil {
+			t.Errorf("%s: base reels or sticky wilds not restored", c.name)
+		}
+	}
+}
+
+// Sticky wilds stay on their positions over free spins and are released after.
+func TestFreeSpinsSticky(t *testing.T) {
+	W, A, K, Q, J := symWild, symAce, symKing, symQueen, symJack
+	sl := scatterSlot(Feature{Name: "sticky", Min: 3, Chance: 100, Spins: []int{0, 0, 0, 20}, Retrigger: []int{0}, Sticky: true,
+		Reels: [][]symbol{{W, A, K, Q, J}, {W, A, K, Q, J}, {W, A, K, Q, J}}})
+	sl.Wilds = []Wild{{Symbol: W, Sticky: true}}
+	if err := sl.Init(2024); err != nil {
+		t.Fatal(err)
+	}
+	round := sl.Play()
+	held := make([]bool, len(sl.Reels))
+	for k, spin := range round.Free {
+		for i, reel := range spin.Grid {
+			if held[i] && reel[0] != W {
+				t.Fatalf("free spin %d: sticky wild on reel %d released", k+1, i+1)
+			}
+			held[i] = held[i] || reel[0] == W
+		}
+	}
+	if !held[0] && !held[1] && !held[2] {
+		t.Errorf("no wild in %d free spins", len(round.Free))
+	}
+	if sl.sticky != nil {
+		t.Errorf("sticky wilds held after feature")
+	}
+}
+
+// Simulation splits rtp among base game and features.
+func TestFeatureSplit(t *testing.T) {
+	sl := scatterSlot(
+		Feature{Name: "free spins", Min: 3, Chance: 100, Spins: []int{0, 0, 0, 5}, Retrigger: []int{0}, Multi: 2},
+		Feature{Name: "pick bonus", Min: 3, Chance: 100, Picks: []int{0, 0, 0, 2}, Prizes: []float64{3}, Weights: []int{1}},
+	)
+	if err := sl.Init(2024); err != nil {
+		t.Fatal(err)
+	}
+	const plays = 1000
+	sl.Simulate(plays)
+	st := &sl.Stats
+	base, free, pick := st.Cats["base"], st.Cats["free spins"], st.Cats["pick bonus"]
+	if base.Cnt != plays || base.Sum != plays {
+		t.Errorf("base %d hits, win %g, want %d", base.Cnt, base.Sum, plays)
+	}
+	if free.Cnt+pick.Cnt != plays || free.Cnt == 0 || pick.Cnt == 0 {
+		t.Errorf("free spins %d and pick bonus %d, want %d in total", free.Cnt, pick.Cnt, plays)
+	}
+	if free.Sum != 10*float64(free.Cnt) || pick.Sum != 6*float64(pick.Cnt) {
+		t.Errorf("free spins win %g of %d, pick bonus win %g of %d", free.Sum, free.Cnt, pick.Sum, pick.Cnt)
+	}
+	if total := base.Sum + free.Sum + pick.Sum; st.Win.Sum != total || st.RTP() != total/plays {
+		t.Errorf("win %g, rtp %g, want split total %g", st.Win.Sum, st.RTP(), total)
+	}
+}
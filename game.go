@@ -47,6 +47,15 @@ type Screen struct {
 	Count   int
 	Hazard  bool
 	Sturm   bool
+	deck    *Deck // dealing deck, Dealer if nil
+}
+
+// Dealing deck of screen.
+func (scr *Screen) dealer() *Deck {
+	if scr.deck == nil {
+		return &Dealer
+	}
+	return scr.deck
 }
 
 func (scr *Screen) History(s string) {
@@ -76,10 +85,10 @@ func (scr *Screen) Strategy() {
 
 // Base game deal.
 func (scr *Screen) Deal() {
-	scr.Hand = Dealer.Deal(4) // 4 cards in hand from new deck
-	scr.Diam = Dealer.Null()  // no cards in diamond yet
-	scr.Strategy()            // swap strategy
-	scr.Swaps = 0             // reset counter
+	scr.Hand = scr.dealer().Deal(4) // 4 cards in hand from new deck
+	scr.Diam = scr.dealer().Null()  // no cards in diamond yet
+	scr.Strategy()                  // swap strategy
+	scr.Swaps = 0                   // reset counter
 	scr.Flow = ""
 	scr.Wait = 5
 	scr.Deck = 52 - len(scr.Hand)
@@ -99,7 +108,7 @@ func (scr *Screen) Deal() {
 
 // Draw card in diamond.
 func (scr *Screen) Draw() int {
-	card := Dealer.Draw()             // draw single card from rest of the deck
+	card := scr.dealer().Draw()       // draw single card from rest of the deck
 	card.Index = len(scr.Diam)        // hand card index
 	scr.Diam = append(scr.Diam, card) // add card to diamond
 	scr.Deck--
@@ -221,7 +230,7 @@ func (scr *Screen) Hunt() (more bool) {
 
 // Play one hand.
 func (scr *Screen) Play(bet float64) HuntResponse {
-	Dealer.Reset()
+	scr.dealer().Reset()
 	// if flip = !flip; flip {
 	// Dealer.AddCheats("Q♦", "Q♠", "Q♥", "Q♣", "J♦", "2♦")
 	// }
@@ -288,9 +297,10 @@ func (scr *Screen) Eval(bet float64) (resp HuntResponse) {
 	resp.Close = scr.Count
 	// scr.Diam = Make("J♦", "Q♦", "K♦", "A♦")
 	resp.Diams = 0
-	for i := len(Dealer.Cards); i > Dealer.Rest; {
+	deck := scr.dealer()
+	for i := len(deck.Cards); i > deck.Rest; {
 		i--
-		j := Dealer.Cards[i]
+		j := deck.Cards[i]
 		c := CardVirtues[j]
 		if c.IsDiam {
 			resp.Diams++
@@ -388,20 +398,62 @@ func AddCat(cat string, x float64) {
 	CatStat[cat] = c
 }
 
+// DiamondHunt simulation state.
+type Hunt struct {
+	Iter     int          `json:"iter"`            // tickets to play
+	Done     int          `json:"done"`            // tickets played
+	Chips    []float64    `json:"chips,omitempty"` // bet values
+	Strategy int          `json:"strategy"`        // swap strategy
+	Hazard   bool         `json:"hazard"`          // screen hazard flag
+	Bet      rng.StatCalc `json:"bet"`             // bets
+	Win      rng.StatCalc `json:"win"`             // wins
+	Ticket   rng.StatCalc `json:"ticket"`          // return per ticket (win / bet)
+	Opens    [5]int       `json:"opens"`           // open diamonds
+	Chart    [5][5]int    `json:"chart"`           // open by closing diamonds
+
+	deck Deck // lock-free dealing deck of run (seeded from Dealer)
+}
+
 func DiamondHunt(iter int, chips ...float64) {
+	hunt := Hunt{Iter: iter, Chips: chips, Strategy: Strategy}
+	hunt.Bet.Cat, hunt.Win.Cat = "bet", "win"
+	hunt.Run()
+}
+
+// Play remaining tickets and show report.
+func (hunt *Hunt) Run() {
+	if hunt.deck.Cards == nil { // new run, not resumed
+		hunt.deck.Croupier.Solo(true) // deck is used by single goroutine
+		hunt.deck.Seed(Dealer.Croupier.Local().Seed())
+	}
 	var scr Screen
+	scr.deck = &hunt.deck
 	scr.Verbose = false
-	var bet, win rng.StatCalc
-	bet.Cat, win.Cat = "bet", "win"
-
-	opens := [5]int{}
-	chart := [5][5]int{}
+	scr.Hazard = hunt.Hazard
+	Strategy = hunt.Strategy
+	bet, win, chips := &hunt.Bet, &hunt.Win, hunt.Chips
+	opens, chart := &hunt.Opens, &hunt.Chart
+
+	var save CheckpointTimer
+	save.Start()
+	var prog Progress
+	prog.Start(hunt.Done)
+	defer prog.Stop()
+
+	for cnt := hunt.Done + 1; cnt <= hunt.Iter; cnt++ {
+		if save.Due(cnt) {
+			hunt.Done, hunt.Hazard = cnt-1, scr.Hazard
+			save.Save(hunt)
+		}
+		if prog.Due(cnt) {
+			hunt.Done = cnt - 1
+			prog.Serve(hunt)
+		}
 
-	for cnt := 1; cnt <= iter; cnt++ {
 		chip := rng.WSOGMM.Value(chips, 1)
 		bet.Add(chip)
 
-		play := 0
+		play, total := 0, 0.
 		for run := 1; run > 0; run-- {
 			scr.Sturm = false
 			play++
@@ -417,6 +469,7 @@ func DiamondHunt(iter int, chips ...float64) {
 				AddCat(cat_win, ans.Win)
 			}
 			if ans.Total > 0 {
+				total += ans.Total
 				win.Add(ans.Total)
 				AddCat("total", ans.Total)
 			}
@@ -441,7 +494,10 @@ func DiamondHunt(iter int, chips ...float64) {
 		}
 
 		AddCat("play", float64(play))
+		hunt.Ticket.Add(total / chip)
 	}
+	hunt.Done, hunt.Hazard = hunt.Iter, scr.Hazard
+	save.Save(hunt)
 
 	play := CatStat["play"]
 
@@ -0,0 +1,21 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import "testing"
+
+// DiamondHunt throughput (Screen.Play) with locked and lock-free dealer.
+func BenchmarkDiamondHunt(b *testing.B) {
+	for _, solo := range []bool{false, true} {
+		name := map[bool]string{false: "locked", true: "solo"}[solo]
+		b.Run(name, func(b *testing.B) {
+			var deck Deck
+			deck.Croupier.Solo(solo)
+			deck.Seed(2024)
+			scr := Screen{deck: &deck}
+			for i := 0; i < b.N; i++ {
+				scr.Play(1)
+			}
+		})
+	}
+}
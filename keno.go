@@ -0,0 +1,123 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"DHSimulator/rng"
+	"fmt"
+)
+
+// Keno balls.
+const KenoBalls = 80
+
+// # Keno
+//
+// Ticket marks 1 to 10 spots of 80 numbers, 20 balls are drawn and ticket
+// pays by spots hit.
+/*
+	prob(hits) = C(spots, hits) · C(80 - spots, draw - hits) / C(80, draw)
+*/
+type Keno struct {
+	Draw  int               `json:"draw"`  // balls drawn
+	Spots int               `json:"spots"` // spots of ticket
+	Pays  map[int][]float64 `json:"pays"`  // pays by spots and hits (index)
+	rnd   rng.LCPRNG        // mixer
+}
+
+// Keno with classic paytable.
+func ClassicKeno(spots int) *Keno {
+	return &Keno{
+		Draw:  20,
+		Spots: spots,
+		Pays: map[int][]float64{
+			1:  {0, 3},
+			2:  {0, 0, 12},
+			3:  {0, 0, 1, 42},
+			4:  {0, 0, 1, 4, 120},
+			5:  {0, 0, 0, 2, 20, 600},
+			6:  {0, 0, 0, 1, 4, 80, 1500},
+			7:  {0, 0, 0, 1, 2, 20, 380, 7000},
+			8:  {0, 0, 0, 0, 2, 10, 80, 1500, 15000},
+			9:  {0, 0, 0, 0, 1, 5, 40, 300, 4000, 40000},
+			10: {5, 0, 0, 0, 0, 2, 20, 130, 1000, 10000, 100000},
+		},
+	}
+}
+
+// Initialize mixer.
+func (k *Keno) Init(seeds ...uint64) error {
+	if k.Draw < 1 || k.Draw > KenoBalls {
+		return fmt.Errorf("keno: bad draw %d", k.Draw)
+	}
+	if k.Spots < 1 || k.Spots > k.Draw {
+		return fmt.Errorf("keno: bad spots %d", k.Spots)
+	}
+	if len(k.Pays[k.Spots]) == 0 {
+		return fmt.Errorf("keno: no pays for %d spots", k.Spots)
+	}
+	k.rnd.Solo(true) // game is used by single goroutine
+	k.rnd.Randomize(seeds...)
+	return nil
+}
+
+// Game name.
+func (k *Keno) Title() string {
+	return fmt.Sprintf("Keno %d spots", k.Spots)
+}
+
+// Quick pick ticket.
+func (k *Keno) Ticket() []int {
+	ticket := k.rnd.Combination(KenoBalls, k.Spots)
+	for i := range ticket {
+		ticket[i]++
+	}
+	return ticket
+}
+
+// Drawn balls in order.
+func (k *Keno) Draws() []int {
+	return k.rnd.Keno()[:k.Draw]
+}
+
+// Spots hit.
+func (k *Keno) Hits(ticket, draws []int) (hits int) {
+	var drawn [KenoBalls + 1]bool
+	for _, b := range draws {
+		drawn[b] = true
+	}
+	for _, n := range ticket {
+		if drawn[n] {
+			hits++
+		}
+	}
+	return
+}
+
+// Pay by hits.
+func (k *Keno) Pay(hits int) float64 {
+	if p := k.Pays[k.Spots]; hits < len(p) {
+		return p[hits]
+	}
+	return 0
+}
+
+// Exact math by hits.
+func (k *Keno) Exact() (hits []DrawHit) {
+	for h := k.Spots; h >= 0; h-- {
+		if pay := k.Pay(h); pay > 0 {
+			prob := rng.MultiHypGeomDist([]int{k.Spots, KenoBalls - k.Spots}, []int{h, k.Draw - h})
+			hits = append(hits, DrawHit{Cat: fmt.Sprintf("%d of %d", h, k.Spots), Prob: prob, Pay: pay, RTP: prob * pay})
+		}
+	}
+	return
+}
+
+// Play round with quick pick ticket.
+func (k *Keno) Round(st *Tally) {
+	h := k.Hits(k.Ticket(), k.Draws())
+	win := k.Pay(h)
+	if win > 0 {
+		st.Add(fmt.Sprintf("%d of %d", h, k.Spots), win)
+	}
+	st.Play(1, win)
+}
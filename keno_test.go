@@ -0,0 +1,80 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import "testing"
+
+// Keno refuses bad draw, spots and missing pays.
+func TestKenoInit(t *testing.T) {
+	for _, c := range []struct {
+		name        string
+		draw, spots int
+		ok          bool
+	}{
+		{"classic", 20, 8, true},
+		{"no draw", 0, 8, false},
+		{"draw over balls", KenoBalls + 1, 8, false},
+		{"no spots", 20, 0, false},
+		{"spots over draw", 5, 6, false},
+		{"no pays", 20, 11, false},
+	} {
+		k := ClassicKeno(c.spots)
+		k.Draw = c.draw
+		if err := k.Init(2024); (err == nil) != c.ok {
+			t.Errorf("%s: Init error %v", c.name, err)
+		}
+	}
+}
+
+// Quick pick tickets and draws are distinct numbers of 1 to 80.
+func TestKenoTicket(t *testing.T) {
+	k := ClassicKeno(10)
+	if err := k.Init(2024); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 1000; i++ {
+		for _, c := range []struct {
+			name    string
+			numbers []int
+			n       int
+		}{{"ticket", k.Ticket(), k.Spots}, {"draws", k.Draws(), k.Draw}} {
+			var seen [KenoBalls + 1]bool
+			for _, n := range c.numbers {
+				if n < 1 || n > KenoBalls || seen[n] {
+					t.Fatalf("%s %v: bad or repeated number %d", c.name, c.numbers, n)
+				}
+				seen[n] = true
+			}
+			if len(c.numbers) != c.n {
+				t.Fatalf("%s %v: %d numbers, want %d", c.name, c.numbers, len(c.numbers), c.n)
+			}
+		}
+	}
+}
+
+// Hits and pays of fixed draws, no pay beyond paytable.
+func TestKenoHits(t *testing.T) {
+	draws := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}
+	for _, c := range []struct {
+		ticket []int
+		hits   int
+		pay    float64
+	}{
+		{[]int{5}, 1, 3},
+		{[]int{80}, 0, 0},
+		{[]int{1, 20, 21, 40}, 2, 1},
+		{[]int{2, 4, 6, 8, 10, 60, 70, 80}, 5, 10},
+		{[]int{21, 22, 23, 24, 25, 26, 27, 28, 29, 30}, 0, 5}, // 0 of 10 pays
+		{[]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 10, 100000},
+	} {
+		k := ClassicKeno(len(c.ticket))
+		h := k.Hits(c.ticket, draws)
+		if pay := k.Pay(h); h != c.hits || pay != c.pay {
+			t.Errorf("ticket %v: %d hits pay %g, want %d hits pay %g", c.ticket, h, pay, c.hits, c.pay)
+		}
+	}
+	k := ClassicKeno(1)
+	if pay := k.Pay(2); pay != 0 {
+		t.Errorf("2 hits of 1 spot pay %g", pay)
+	}
+}
@@ -0,0 +1,229 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"strings"
+)
+
+// Low games.
+const (
+	LowAceFive    = iota // ace-to-five: ace low, straights and flushes ignored
+	LowDeuceSeven        // deuce-to-seven: ace high, straights and flushes count
+	LowEight             // ace-to-five, 8 or better qualifier
+)
+
+/*
+Returns low hand code of 5 cards hand in format
+
+	"SXXXXX L low name"
+
+where S is one digit low strength and XXXXX are kinds in order of significance,
+both inverted, so better low has greater code (as hand codes). Empty if no qualifying low.
+Rank is the same digits as base 13 number (better low has greater rank, 0 if no low).
+
+	9 = no pair                 (5-4-3-2-A low)
+	8 = 1 pair
+	7 = 2 pair
+	6 = 3 of kind
+	5 = straight                (deuce-to-seven)
+	4 = flush                   (deuce-to-seven)
+	3 = full house              (5 for ace-to-five)
+	2 = 4 of kind               (4 for ace-to-five)
+	1 = straight flush          (deuce-to-seven)
+*/
+func (bp *BitPoker) lowCode(hand bits, game int) (code string, rank int) {
+	var count [13]int // by low value
+	sm := 0           // suits mask
+	for p := hand; p != 0; p &= p - 1 {
+		c := bp.Rightmost(p)
+		v := c >> 2
+		if game != LowDeuceSeven {
+			v = (v + 1) % 13 // ace low
+		}
+		count[v]++
+		sm |= 1 << (c & 3)
+	}
+
+	// kinds in order of significance, pattern of counts
+	vs, pattern := make([]int, 0, 5), 0
+	for c := 4; c > 0; c-- {
+		for v := 12; v >= 0; v-- {
+			if count[v] == c {
+				for i := 0; i < c; i++ {
+					vs = append(vs, v)
+				}
+				pattern = 10*pattern + c
+			}
+		}
+	}
+	if len(vs) != 5 {
+		return "", 0
+	}
+
+	var cat, name int // low category, hand name
+	switch pattern {
+	case 41:
+		cat, name = 7, 7
+	case 32:
+		cat, name = 6, 6
+	case 311:
+		cat, name = 3, 3
+	case 221:
+		cat, name = 2, 2
+	case 2111:
+		cat, name = 1, 1
+	default:
+		if game == LowDeuceSeven {
+			straight := vs[0]-vs[4] == 4
+			flush := sm&(sm-1) == 0
+			switch {
+			case straight && flush:
+				cat, name = 8, 8
+			case flush:
+				cat, name = 5, 5
+			case straight:
+				cat, name = 4, 4
+			}
+		}
+	}
+	if game != LowDeuceSeven && cat > 3 {
+		cat -= 2 // no straights and flushes
+	}
+	if game == LowEight && (cat > 0 || vs[0] > 7) {
+		return "", 0 // not 8 or better
+	}
+
+	b, rank := []byte{digit(9 - cat)}, 9-cat
+	for _, v := range vs {
+		b = append(b, digit(12-v))
+		rank = 13*rank + 12 - v
+	}
+	b = append(b, " L "...)
+	if cat == 0 {
+		kinds := make([]string, 5)
+		for i, v := range vs {
+			if game != LowDeuceSeven {
+				v = (v + 12) % 13 // back to kind
+			}
+			kinds[i] = bp.Kinds[v]
+		}
+		b = append(b, strings.Join(kinds, "-")+" low"...)
+	} else {
+		b = append(b, bp.Names[name]...)
+	}
+	return string(b), rank
+}
+
+// Low rule: low code ("" if none), its rank (greater is better, 0 if none)
+// and selected cards from hold and desk.
+type LowRule func(hold, desk bits) (code string, rank int, mh, md bits)
+
+// Best low hand code ("" if none) and rank (0 if none) of 5 cards from pile.
+func (bp *BitPoker) Low(pile bits, game int) (string, int) {
+	code, rank, _, _ := bp.LowHoldem(0, pile, 0, game)
+	return code, rank
+}
+
+// Best low hand with keep number of cards from hold and rest from desk.
+//
+// Returns low code ("" if none), its rank (0 if none)
+// and selected cards from hold and desk.
+func (bp *BitPoker) LowHoldem(hold, desk bits, keep, game int) (mc string, mr int, mh, md bits) {
+	bp.ensure()
+	hold, desk = hold&bp.pack, desk&bp.pack
+	desk ^= hold & desk
+
+	var sh, sd sampler
+	if sh.Init(hold, keep) && sd.Init(desk, 5-keep) {
+		for !sh.Eof() {
+			h := sh.Next()
+			sd.Reset()
+			for !sd.Eof() {
+				d := sd.Next()
+				if c, r := bp.lowCode(h|d, game); r > mr {
+					mc, mr, mh, md = c, r, h, d
+				}
+			}
+		}
+	}
+	return
+}
+
+// Omaha low: 8 or better with 2 cards from hold and 3 from desk.
+func (bp *BitPoker) OmahaLow(hold, desk bits) (string, int, bits, bits) {
+	return bp.LowHoldem(hold, desk, 2, LowEight)
+}
+
+// Stud low: 8 or better from any 5 cards of hold and desk.
+func (bp *BitPoker) StudLow(hold, desk bits) (string, int, bits, bits) {
+	desk ^= hold & desk
+	code, rank, _, best := bp.LowHoldem(0, hold|desk, 0, LowEight)
+	return code, rank, hold & best, desk & best
+}
+
+// Determines players low standings according to given low rule.
+//
+// Hands are compared by low rank, standings go from best to worst.
+func (bp *BitPoker) PlayLowHand(players []bits, desk bits, rule LowRule) ([]string, [][]int) {
+	return standings(len(players), func(p int) (string, int) {
+		code, rank, _, _ := rule(players[p], desk)
+		return code, rank
+	})
+}
+
+// Hi/lo showdown.
+type HiLo struct {
+	Hi    []string  `json:"hi"`    // high codes by place
+	Lo    []string  `json:"lo"`    // low codes by place ("" = no low)
+	High  [][]int   `json:"high"`  // high standings
+	Low   [][]int   `json:"low"`   // low standings
+	Share []float64 `json:"share"` // pot share by player
+}
+
+// Determines players high and low standings and pot shares according to given rules.
+//
+// Half of pot goes to best high hands and half to best qualifying low hands,
+// high hands scoop if there is no qualifying low.
+func (bp *BitPoker) PlayHiLoHand(players []bits, desk bits, hi func(hold, desk bits) (string, bits, bits), lo LowRule) (hl HiLo) {
+	hl.Hi, hl.High = bp.PlayPokerHand(players, desk, hi)
+	hl.Lo, hl.Low = bp.PlayLowHand(players, desk, lo)
+	hl.Share = make([]float64, len(players))
+	split := func(winners []int, pot float64) {
+		for _, p := range winners {
+			hl.Share[p] += pot / float64(len(winners))
+		}
+	}
+	if len(hl.Lo) > 0 && hl.Lo[0] != "" {
+		split(hl.High[0], 0.5)
+		split(hl.Low[0], 0.5)
+	} else if len(hl.High) > 0 {
+		split(hl.High[0], 1)
+	}
+	return
+}
+
+// Determines players standings according to Omaha Hi-Lo rule.
+func (bp *BitPoker) PlayOmahaHiLo(players []bits, desk bits) HiLo {
+	return bp.PlayHiLoHand(players, desk, bp.OmahaHoldem, bp.OmahaLow)
+}
+
+// Determines players standings according to Stud Hi-Lo rule (7 cards in hold).
+func (bp *BitPoker) PlayStudHiLo(players []bits) HiLo {
+	return bp.PlayHiLoHand(players, 0, bp.TexasHoldem, bp.StudLow)
+}
+
+// Low hand code and rank of engine cards.
+func (pok *Poker) Low(pile []int, game int) (string, int) {
+	return pok.bp.Low(pok.bp.Squeeze(pile...), game)
+}
+
+// Determines players standings according to Omaha Hi-Lo rule.
+func (pok *Poker) PlayOmahaHiLo(players [][]int, desk []int) HiLo {
+	return pok.bp.PlayOmahaHiLo(pok.piles(players), pok.bp.Squeeze(desk...))
+}
+
+// Determines players standings according to Stud Hi-Lo rule.
+func (pok *Poker) PlayStudHiLo(players [][]int) HiLo {
+	return pok.bp.PlayStudHiLo(pok.piles(players))
+}
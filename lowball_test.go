@@ -0,0 +1,67 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import "testing"
+
+// Fixed low hands order of ace-to-five, deuce-to-seven and 8 or better.
+func TestLowHands(t *testing.T) {
+	var bp BitPoker
+	bp.Classic()
+	rank := func(cards string, game int) int {
+		_, r := bp.Low(parsePile(t, &bp, cards), game)
+		return r
+	}
+	for _, c := range []struct {
+		name          string
+		better, worse string
+		game          int
+	}{
+		{"deuce-to-seven number one", "7s5d4h3c2s", "8s4d3h2cAs", LowDeuceSeven},
+		{"deuce-to-seven ace high", "8s4d3h2c7s", "As5d4h3c2s", LowDeuceSeven},
+		{"deuce-to-seven straight", "8s7d6h5c3s", "6s5d4h3c2s", LowDeuceSeven},
+		{"deuce-to-seven flush", "Ks5d4h3c2s", "7s5s4s3s2s", LowDeuceSeven},
+		{"ace-to-five wheel", "5s4d3h2cAs", "6s4d3h2cAs", LowAceFive},
+		{"ace-to-five suited wheel", "5s4d3h2cAs", "7s5s4s3s2s", LowAceFive},
+		{"ace-to-five pair", "Ks Qd Jh Tc 9s", "As Ad 2h 3c 4s", LowAceFive},
+		{"8 or better", "8s7d6h5c4s", "9s4d3h2cAs", LowEight},
+	} {
+		if b, w := rank(c.better, c.game), rank(c.worse, c.game); b <= w {
+			t.Errorf("%s: %s (rank %d) must beat %s (rank %d)", c.name, c.better, b, c.worse, w)
+		}
+	}
+	if code, _ := bp.Low(parsePile(t, &bp, "As2d3h4c5s"), LowAceFive); code != "989ABC L 5-4-3-2-A low" {
+		t.Errorf("wheel low code %q", code)
+	}
+	wheel := rank("5s4d3h2cAs", LowAceFive)
+	for _, cards := range []string{"5s4s3s2sAs", "5d4h3c2sAd"} { // flushes ignored
+		if r := rank(cards, LowAceFive); r != wheel {
+			t.Errorf("ace-to-five %s rank %d, wheel %d", cards, r, wheel)
+		}
+	}
+	if code, r := bp.Low(parsePile(t, &bp, "9s4d3h2cAs"), LowEight); code != "" || r != 0 {
+		t.Errorf("9 low qualifies for 8 or better: %q (rank %d)", code, r)
+	}
+}
+
+// Omaha hi/lo splits pot, high scoops without qualifying low.
+func TestOmahaHiLo(t *testing.T) {
+	var bp BitPoker
+	bp.Classic()
+	players := []bits{parsePile(t, &bp, "AsKsQdJd"), parsePile(t, &bp, "Ah2h9c9d")}
+	for _, c := range []struct {
+		desk  string
+		share []float64
+	}{
+		{"Kd Kh 3c 4d 8s", []float64{0.5, 0.5}}, // trips kings high, 8-4-3-2-A low
+		{"Kd Kh 3c Td 8s", []float64{1, 0}},     // no low
+	} {
+		hl := bp.PlayOmahaHiLo(players, parsePile(t, &bp, c.desk))
+		for i, s := range c.share {
+			if hl.Share[i] != s {
+				t.Errorf("desk %s: shares %v, want %v (hi %v, lo %v)", c.desk, hl.Share, c.share, hl.Hi, hl.Lo)
+				break
+			}
+		}
+	}
+}
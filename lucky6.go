@@ -0,0 +1,118 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"DHSimulator/rng"
+	"fmt"
+)
+
+// Lucky 6 balls and ticket numbers.
+const (
+	Lucky6Balls   = 49
+	Lucky6Numbers = 6
+)
+
+// # Lucky 6
+//
+// Ticket has 6 of 49 numbers, 35 balls are drawn in sequence and ticket
+// pays by ball which completes it (6th to 35th). Ticket is complete by
+// ball n when all 6 numbers are among first n:
+/*
+	P(n) = C(6, 6) · C(43, n - 6) / C(49, n)
+	prob(n) = P(n) - P(n - 1)
+*/
+type Lucky6 struct {
+	Draw int        `json:"draw"` // balls drawn
+	Pays []float64  `json:"pays"` // pays by completing ball (index)
+	rnd  rng.LCPRNG // mixer
+}
+
+// Lucky 6 with classic odds.
+func ClassicLucky6() *Lucky6 {
+	return &Lucky6{
+		Draw: 35,
+		Pays: []float64{0, 0, 0, 0, 0, 0,
+			10000, 7500, 5000, 2500, 1000, 500, 300, 200, 150, 100,
+			90, 80, 70, 60, 50, 40, 30, 25, 20, 15,
+			10, 9, 8, 7, 6, 5, 4, 3, 2, 1},
+	}
+}
+
+// Initialize mixer.
+func (l *Lucky6) Init(seeds ...uint64) error {
+	if l.Draw < Lucky6Numbers || l.Draw > Lucky6Balls {
+		return fmt.Errorf("lucky 6: bad draw %d", l.Draw)
+	}
+	l.rnd.Solo(true) // game is used by single goroutine
+	l.rnd.Randomize(seeds...)
+	return nil
+}
+
+// Game name.
+func (l *Lucky6) Title() string {
+	return "Lucky 6"
+}
+
+// Quick pick ticket.
+func (l *Lucky6) Ticket() []int {
+	ticket := l.rnd.Combination(Lucky6Balls, Lucky6Numbers)
+	for i := range ticket {
+		ticket[i]++
+	}
+	return ticket
+}
+
+// Drawn balls in order.
+func (l *Lucky6) Draws() []int {
+	return l.rnd.Lucky6()[:l.Draw]
+}
+
+// Ball which completes ticket, 0 if not complete.
+func (l *Lucky6) Complete(ticket, draws []int) int {
+	var marked [Lucky6Balls + 1]bool
+	for _, n := range ticket {
+		marked[n] = true
+	}
+	hits := 0
+	for i, b := range draws {
+		if marked[b] {
+			if hits++; hits == len(ticket) {
+				return i + 1
+			}
+		}
+	}
+	return 0
+}
+
+// Pay by completing ball.
+func (l *Lucky6) Pay(ball int) float64 {
+	if ball > 0 && ball < len(l.Pays) && ball <= l.Draw {
+		return l.Pays[ball]
+	}
+	return 0
+}
+
+// Exact math by completing ball.
+func (l *Lucky6) Exact() (hits []DrawHit) {
+	last := 0.
+	for n := Lucky6Numbers; n <= l.Draw; n++ {
+		all := rng.MultiHypGeomDist([]int{Lucky6Numbers, Lucky6Balls - Lucky6Numbers}, []int{Lucky6Numbers, n - Lucky6Numbers})
+		prob := all - last
+		last = all
+		if pay := l.Pay(n); pay > 0 {
+			hits = append(hits, DrawHit{Cat: fmt.Sprintf("ball %d", n), Prob: prob, Pay: pay, RTP: prob * pay})
+		}
+	}
+	return
+}
+
+// Play round with quick pick ticket.
+func (l *Lucky6) Round(st *Tally) {
+	ball := l.Complete(l.Ticket(), l.Draws())
+	win := l.Pay(ball)
+	if win > 0 {
+		st.Add(fmt.Sprintf("ball %d", ball), win)
+	}
+	st.Play(1, win)
+}
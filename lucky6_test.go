@@ -0,0 +1,72 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import "testing"
+
+// Lucky 6 refuses draw shorter than ticket or longer than balls.
+func TestLucky6Init(t *testing.T) {
+	for _, c := range []struct {
+		draw int
+		ok   bool
+	}{{35, true}, {Lucky6Numbers, true}, {Lucky6Numbers - 1, false}, {Lucky6Balls + 1, false}} {
+		l := ClassicLucky6()
+		l.Draw = c.draw
+		if err := l.Init(2024); (err == nil) != c.ok {
+			t.Errorf("draw %d: Init error %v", c.draw, err)
+		}
+	}
+}
+
+// Quick pick tickets are 6 distinct numbers of 1 to 49.
+func TestLucky6Ticket(t *testing.T) {
+	l := ClassicLucky6()
+	if err := l.Init(2024); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 1000; i++ {
+		ticket := l.Ticket()
+		var seen [Lucky6Balls + 1]bool
+		for _, n := range ticket {
+			if n < 1 || n > Lucky6Balls || seen[n] {
+				t.Fatalf("ticket %v: bad or repeated number %d", ticket, n)
+			}
+			seen[n] = true
+		}
+		if len(ticket) != Lucky6Numbers {
+			t.Fatalf("ticket %v: %d numbers", ticket, len(ticket))
+		}
+	}
+}
+
+// Completing ball and its pay of fixed draws.
+func TestLucky6Complete(t *testing.T) {
+	l := ClassicLucky6()
+	draws := make([]int, l.Draw)
+	for i := range draws {
+		draws[i] = Lucky6Balls - i // 49, 48, ..., 15
+	}
+	for _, c := range []struct {
+		ticket []int
+		ball   int
+		pay    float64
+	}{
+		{[]int{49, 48, 47, 46, 45, 44}, 6, 10000},
+		{[]int{44, 45, 46, 47, 48, 49}, 6, 10000}, // order of ticket does not matter
+		{[]int{49, 48, 47, 46, 45, 30}, 20, 50},
+		{[]int{49, 48, 47, 46, 45, 15}, 35, 1},
+		{[]int{49, 48, 47, 46, 45, 14}, 0, 0}, // not drawn
+	} {
+		ball := l.Complete(c.ticket, draws)
+		if pay := l.Pay(ball); ball != c.ball || pay != c.pay {
+			t.Errorf("ticket %v: ball %d pay %g, want ball %d pay %g", c.ticket, ball, pay, c.ball, c.pay)
+		}
+	}
+	l.Draw = 30
+	if pay := l.Pay(35); pay != 0 {
+		t.Errorf("ball 35 of 30 drawn pays %g", pay)
+	}
+	if pay := l.Pay(len(l.Pays)); pay != 0 {
+		t.Errorf("ball beyond paytable pays %g", pay)
+	}
+}
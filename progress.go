@@ -0,0 +1,146 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"time"
+)
+
+var (
+	ProgressEvery = 10 * time.Second // progress output interval, none if 0
+	StatusAddr    = ""               // HTTP status endpoint address (e.g. ":8080"), none if empty
+)
+
+// Live statistics of running DiamondHunt.
+type Status struct {
+	Done     int            `json:"done"`     // tickets played
+	Iter     int            `json:"iter"`     // tickets to play
+	Speed    float64        `json:"speed"`    // tickets per second
+	Elapsed  float64        `json:"elapsed"`  // seconds
+	ETA      float64        `json:"eta"`      // seconds to finish
+	RTP      float64        `json:"rtp"`      // running return to player
+	CI       float64        `json:"ci"`       // 95% confidence interval half width
+	Jackpots map[string]int `json:"jackpots"` // jackpot counts
+}
+
+// Current statistics of hunt.
+func (hunt *Hunt) Status(elapsed float64, done int) (st Status) {
+	const z = 1.959963984540054 // 95% two-sided normal quantile
+	st.Done, st.Iter, st.Elapsed = hunt.Done, hunt.Iter, elapsed
+	if elapsed > 0 {
+		st.Speed = float64(done) / elapsed
+	}
+	if st.Speed > 0 {
+		st.ETA = float64(hunt.Iter-hunt.Done) / st.Speed
+	}
+	if hunt.Bet.Sum > 0 {
+		st.RTP = hunt.Win.Sum / hunt.Bet.Sum
+	}
+	if n := hunt.Ticket.Cnt; n > 1 {
+		st.CI = z * hunt.Ticket.Dev / math.Sqrt(float64(n))
+	}
+	st.Jackpots = map[string]int{}
+	for _, cat := range []string{cat_handy, cat_straight, cat_four} {
+		st.Jackpots[cat] = CatStat[cat].Cnt
+	}
+	return
+}
+
+// Print status line.
+func (st Status) Print() {
+	dur := func(s float64) time.Duration {
+		return time.Duration(s * float64(time.Second)).Round(time.Second)
+	}
+	fmt.Printf("%d / %d tickets (%.2f%%)  %.0f tickets / s  elapsed %v  ETA %v  rtp = %.5f%% ± %.5f%%  jackpots %d / %d / %d\n",
+		st.Done, st.Iter, 100*float64(st.Done)/float64(st.Iter), st.Speed, dur(st.Elapsed), dur(st.ETA),
+		100*st.RTP, 100*st.CI, st.Jackpots[cat_handy], st.Jackpots[cat_straight], st.Jackpots[cat_four])
+}
+
+// Progress reporter.
+//
+// Simulation loop polls reporter, so statistics are read
+// only by simulation goroutine, even for HTTP and signal requests.
+type Progress struct {
+	every time.Duration    // output interval
+	start time.Time        // start time
+	last  time.Time        // last output
+	first int              // tickets played before start
+	ask   chan chan Status // status requests
+	done  chan struct{}    // run finished
+	srv   *http.Server     // status endpoint
+}
+
+// Start reporter with global settings.
+func (pr *Progress) Start(done int) {
+	pr.every, pr.first = ProgressEvery, done
+	pr.start = time.Now()
+	pr.last = pr.start
+	pr.ask, pr.done = make(chan chan Status), make(chan struct{})
+	notifySignal(pr) // SIGUSR1 handler, if supported
+	if StatusAddr != "" {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+			if st, ok := pr.Request(); ok {
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(st)
+			} else {
+				http.Error(w, "run finished", http.StatusGone)
+			}
+		})
+		pr.srv = &http.Server{Addr: StatusAddr, Handler: mux}
+		go func() {
+			if err := pr.srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				fmt.Fprintln(os.Stderr, "status:", err)
+			}
+		}()
+	}
+}
+
+// Stop reporter.
+func (pr *Progress) Stop() {
+	close(pr.done)
+	if pr.srv != nil {
+		pr.srv.Close()
+	}
+}
+
+// Request status from simulation goroutine, false if run finished.
+func (pr *Progress) Request() (Status, bool) {
+	reply := make(chan Status, 1)
+	select {
+	case pr.ask <- reply:
+		return <-reply, true
+	case <-pr.done:
+		return Status{}, false
+	}
+}
+
+// Is poll due before ticket cnt?
+func (pr *Progress) Due(cnt int) bool {
+	const mask = 1<<14 - 1
+	return cnt&mask == 0
+}
+
+// Answer pending requests and print progress when interval passed.
+func (pr *Progress) Serve(hunt *Hunt) {
+	status := func() Status {
+		return hunt.Status(time.Since(pr.start).Seconds(), hunt.Done-pr.first)
+	}
+	for more := true; more; {
+		select {
+		case reply := <-pr.ask:
+			reply <- status()
+		default:
+			more = false
+		}
+	}
+	if pr.every > 0 && time.Since(pr.last) >= pr.every {
+		status().Print()
+		pr.last = time.Now()
+	}
+}
@@ -0,0 +1,30 @@
+//go:build !windows
+
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// Dump current statistics on SIGUSR1 without stopping the run.
+func notifySignal(pr *Progress) {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGUSR1)
+	go func() {
+		defer signal.Stop(sig)
+		for {
+			select {
+			case <-sig:
+				if st, ok := pr.Request(); ok {
+					st.Print()
+				}
+			case <-pr.done:
+				return
+			}
+		}
+	}()
+}
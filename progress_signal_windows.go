@@ -0,0 +1,6 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+// No SIGUSR1 on Windows, use HTTP status endpoint instead.
+func notifySignal(pr *Progress) {}
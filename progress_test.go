@@ -0,0 +1,83 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"DHSimulator/rng"
+	"math"
+	"testing"
+	"time"
+)
+
+// Status of known tally: speed, ETA, rtp, 95% confidence interval and jackpots.
+func TestStatus(t *testing.T) {
+	defer func(cats map[string]rng.StatCalc) { CatStat = cats }(CatStat)
+	CatStat = map[string]rng.StatCalc{}
+	AddCat(cat_four, 100)
+	AddCat(cat_four, 100)
+
+	hunt := Hunt{Iter: 100, Done: 40}
+	hunt.Bet.Add(1, 1, 1, 1)
+	hunt.Win.Add(0, 0, 2, 2)
+	hunt.Ticket.Add(0, 0, 2, 2) // σ = 1
+	st := hunt.Status(2, 20)    // 20 tickets in 2 seconds
+	for _, c := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"speed", st.Speed, 10},
+		{"eta", st.ETA, 6},
+		{"rtp", st.RTP, 1},
+		{"ci", st.CI, 1.959963984540054 / 2},
+	} {
+		if math.Abs(c.got-c.want) > 1e-12 {
+			t.Errorf("%s %g, want %g", c.name, c.got, c.want)
+		}
+	}
+	if st.Done != 40 || st.Iter != 100 || st.Elapsed != 2 {
+		t.Errorf("done %d of %d in %g s, want 40 of 100 in 2 s", st.Done, st.Iter, st.Elapsed)
+	}
+	if st.Jackpots[cat_four] != 2 || st.Jackpots[cat_handy] != 0 || st.Jackpots[cat_straight] != 0 {
+		t.Errorf("jackpots %v", st.Jackpots)
+	}
+	if st := (&Hunt{Iter: 100}).Status(0, 0); st.Speed != 0 || st.ETA != 0 || st.RTP != 0 || st.CI != 0 {
+		t.Errorf("status before start %+v", st)
+	}
+}
+
+// Requests are answered by simulation goroutine (Serve) until reporter stops.
+func TestProgressRequest(t *testing.T) {
+	defer func(every time.Duration, addr string) { ProgressEvery, StatusAddr = every, addr }(ProgressEvery, StatusAddr)
+	ProgressEvery, StatusAddr = 0, ""
+
+	hunt := Hunt{Iter: 100, Done: 40}
+	var pr Progress
+	pr.Start(hunt.Done)
+	type answer struct {
+		st Status
+		ok bool
+	}
+	reply := make(chan answer)
+	go func() {
+		st, ok := pr.Request()
+		reply <- answer{st, ok}
+	}()
+	var a answer
+	for served := false; !served; {
+		pr.Serve(&hunt) // simulation loop poll
+		select {
+		case a = <-reply:
+			served = true
+		default:
+			time.Sleep(time.Millisecond)
+		}
+	}
+	if !a.ok || a.st.Done != 40 || a.st.Iter != 100 {
+		t.Errorf("status %+v (ok %v), want 40 of 100", a.st, a.ok)
+	}
+
+	pr.Stop()
+	if st, ok := pr.Request(); ok {
+		t.Errorf("status %+v after stop", st)
+	}
+}
@@ -0,0 +1,417 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"DHSimulator/rng"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Hole cards with weight.
+type Combo struct {
+	Hold   bits    `json:"hold"`   // two cards
+	Weight float64 `json:"weight"` // relative frequency (0, 1]
+}
+
+// # Holdem hand range
+//
+// Range is list of distinct hole cards combos.
+type Range []Combo
+
+// Card from kind and suit letters ("As", "T♥", ...), false if unknown.
+func (bp *BitPoker) card(kind, suit string) (c int, ok bool) {
+	k, s := -1, -1
+	for i, n := range bp.Kinds {
+		if strings.EqualFold(n, kind) {
+			k = i
+		}
+	}
+	for i, n := range bp.Suits {
+		if n == suit || strings.EqualFold("sdhc"[i:i+1], suit) { // preferans order
+			s = i
+		}
+	}
+	return 4*k + s, k >= 0 && s >= 0
+}
+
+// Parse cards written as kinds and suits ("AsKh", "A♠ K♥", ...).
+func (bp *BitPoker) Parse(cards string) (pile bits, err error) {
+	bp.ensure()
+	r := []rune(strings.Join(strings.Fields(cards), ""))
+	if len(r)%2 != 0 {
+		return 0, fmt.Errorf("cards: bad cards %q", cards)
+	}
+	for i := 0; i < len(r); i += 2 {
+		c, ok := bp.card(string(r[i]), string(r[i+1]))
+		if !ok || bp.pack>>c&1 == 0 {
+			return 0, fmt.Errorf("cards: bad card %q", string(r[i:i+2]))
+		}
+		if pile>>c&1 != 0 {
+			return 0, fmt.Errorf("cards: duplicate card %q", string(r[i:i+2]))
+		}
+		pile |= 1 << c
+	}
+	return
+}
+
+// Hand class: high and low kinds and suitedness ('s', 'o' or 0 for both).
+type class struct {
+	hi, lo int
+	suit   byte
+}
+
+// Parse hand class ("AKs", "QQ", "T9o").
+func (bp *BitPoker) class(hand string) (cl class, ok bool) {
+	if n := len(hand); n == 3 {
+		cl.suit = hand[2] | 0x20 // lower case
+		ok = cl.suit == 's' || cl.suit == 'o'
+	} else {
+		ok = n == 2
+	}
+	if ok {
+		var h, l int
+		h, ok = bp.kind(hand[0:1])
+		if ok {
+			l, ok = bp.kind(hand[1:2])
+		}
+		if h < l {
+			h, l = l, h
+		}
+		cl.hi, cl.lo = h, l
+		ok = ok && !(h == l && cl.suit != 0) // no suited or offsuit pairs
+	}
+	return
+}
+
+// Kind index of kind letter.
+func (bp *BitPoker) kind(kind string) (int, bool) {
+	c, ok := bp.card(kind, "s")
+	return c >> 2, ok
+}
+
+// Combos of hand class.
+func (cl class) combos() (list []bits) {
+	for s := 0; s < 4; s++ {
+		for t := 0; t < 4; t++ {
+			if cl.hi == cl.lo && s >= t {
+				continue
+			}
+			if (cl.suit == 's' && s != t) || (cl.suit == 'o' && s == t) {
+				continue
+			}
+			list = append(list, 1<<(4*cl.hi+s)|1<<(4*cl.lo+t))
+		}
+	}
+	return
+}
+
+/*
+Parse hand range in standard notation, comma separated list of
+
+	AKs       suited, offsuit (AKo) or both (AK)
+	QQ        pair
+	AsKh      exact hole cards
+	QQ+       pairs QQ and better
+	ATs+      kicker up to AKs
+	T9s-76s   connectors from T9s down to 76s
+	A2s-A5s   kickers from A2s to A5s
+	22-55     pairs from 22 to 55
+	AKs:0.5   any of above with weight
+
+Combos with cards outside of pack are dropped,
+repeated combos take last weight.
+*/
+func (bp *BitPoker) ParseRange(text string) (rg Range, err error) {
+	bp.ensure()
+	index := map[bits]int{} // combo position in range
+	add := func(hold bits, w float64) {
+		if hold&bp.pack != hold {
+			return
+		}
+		if i, ok := index[hold]; ok {
+			rg[i].Weight = w
+		} else {
+			index[hold] = len(rg)
+			rg = append(rg, Combo{hold, w})
+		}
+	}
+
+	for _, token := range strings.Split(text, ",") {
+		token = strings.Join(strings.Fields(token), "")
+		if token == "" {
+			continue
+		}
+		bad := fmt.Errorf("range: bad hand %q", token)
+
+		w := 1.0
+		if i := strings.IndexByte(token, ':'); i >= 0 {
+			if w, err = strconv.ParseFloat(token[i+1:], 64); err != nil || w <= 0 || w > 1 {
+				return nil, fmt.Errorf("range: bad weight %q", token)
+			}
+			token = token[:i]
+		}
+
+		if hold, e := bp.Parse(token); e == nil && bp.Length(hold) == 2 { // exact cards
+			add(hold, w)
+			continue
+		}
+
+		var classes []class
+		switch first, last, dash := strings.Cut(token, "-"); {
+		case dash:
+			a, ok := bp.class(first)
+			b, ok2 := bp.class(last)
+			if !ok || !ok2 || a.suit != b.suit {
+				return nil, bad
+			}
+			if b.hi > a.hi || (b.hi == a.hi && b.lo > a.lo) {
+				a, b = b, a // from higher
+			}
+			switch {
+			case a.hi == a.lo && b.hi == b.lo: // pairs
+				for k := b.hi; k <= a.hi; k++ {
+					classes = append(classes, class{k, k, 0})
+				}
+			case a.hi == b.hi: // kickers
+				for k := b.lo; k <= a.lo; k++ {
+					classes = append(classes, class{a.hi, k, a.suit})
+				}
+			case a.hi-a.lo == b.hi-b.lo: // connectors
+				for d := 0; d <= a.hi-b.hi; d++ {
+					classes = append(classes, class{b.hi + d, b.lo + d, a.suit})
+				}
+			default:
+				return nil, bad
+			}
+		case strings.HasSuffix(token, "+"):
+			a, ok := bp.class(strings.TrimSuffix(token, "+"))
+			if !ok {
+				return nil, bad
+			}
+			if a.hi == a.lo {
+				for k := a.hi; k < 13; k++ {
+					classes = append(classes, class{k, k, 0})
+				}
+			} else {
+				for k := a.lo; k < a.hi; k++ {
+					classes = append(classes, class{a.hi, k, a.suit})
+				}
+			}
+		default:
+			a, ok := bp.class(token)
+			if !ok {
+				return nil, bad
+			}
+			classes = append(classes, a)
+		}
+		for _, cl := range classes {
+			for _, hold := range cl.combos() {
+				add(hold, w)
+			}
+		}
+	}
+	return rg, nil
+}
+
+// Range of exact hole cards.
+func HandRange(hold bits) Range {
+	return Range{{hold, 1}}
+}
+
+// Card removal: combos without dead cards.
+func (rg Range) Remove(dead bits) (r Range) {
+	for _, c := range rg {
+		if c.Hold&dead == 0 {
+			r = append(r, c)
+		}
+	}
+	return
+}
+
+// Total weight of combos.
+func (rg Range) Weight() (w float64) {
+	for _, c := range rg {
+		w += c.Weight
+	}
+	return
+}
+
+// Range view as weighted combos, strongest kinds first.
+func (bp *BitPoker) RangeView(rg Range) []string {
+	r := append(Range{}, rg...)
+	sort.SliceStable(r, func(i, j int) bool { return r[i].Hold > r[j].Hold })
+	view := make([]string, len(r))
+	for i, c := range r {
+		view[i] = strings.Join(bp.Humanize(c.Hold), "")
+		if c.Weight != 1 {
+			view[i] += ":" + strconv.FormatFloat(c.Weight, 'g', -1, 64)
+		}
+	}
+	return view
+}
+
+// # Texas Holdem range equity
+//
+// Each player holds weighted combo from own range,
+// combos sharing cards with desk, dead cards or each other never meet.
+// Exact if at most EquityExact deals, else EquityTrials random deals.
+func (bp *BitPoker) RangeEquity(ranges []Range, desk, dead bits) (odds Odds, err error) {
+	bp.ensure()
+	bp.Rank(0) // build tables
+
+	known := (desk | dead) & bp.pack
+	if desk&dead != 0 || desk&^bp.pack != 0 || bp.Length(desk) > 5 {
+		return odds, fmt.Errorf("equity: bad desk %v", bp.Humanize(desk))
+	}
+	n, need := len(ranges), 5-bp.Length(desk)
+	live := make([]Range, n)
+	deals := rng.Binomial(bp.Length(bp.pack&^known)-2*n, need)
+	for i, rg := range ranges {
+		if live[i] = rg.Remove(known); len(live[i]) == 0 {
+			return odds, fmt.Errorf("equity: empty range of player %d", i+1)
+		}
+		deals *= float64(len(live[i]))
+	}
+
+	// weighted showdown of complete deal
+	win, tie, share := make([]float64, n), make([]float64, n), make([]float64, n)
+	holds, ranks := make([]bits, n), make([]int, n)
+	total := 0.0
+	settle := func(board bits, w float64) {
+		best, cnt := 0, 0
+		for i, h := range holds {
+			r := bp.Rank(h | board)
+			if ranks[i] = r; r > best {
+				best, cnt = r, 1
+			} else if r == best {
+				cnt++
+			}
+		}
+		for i, r := range ranks {
+			if r == best {
+				if cnt == 1 {
+					win[i] += w
+				} else {
+					tie[i] += w
+				}
+				share[i] += w / float64(cnt)
+			}
+		}
+		total += w
+		odds.Deals++
+	}
+
+	if odds.Exact = deals <= float64(EquityExact); odds.Exact {
+		var s sampler
+		var pick func(i int, used bits, w float64)
+		pick = func(i int, used bits, w float64) {
+			if i == n {
+				for s.Init(bp.pack&^used, need); !s.Eof(); {
+					settle(desk|s.Next(), w)
+				}
+				return
+			}
+			for _, c := range live[i] {
+				if c.Hold&used == 0 {
+					holds[i] = c.Hold
+					pick(i+1, used|c.Hold, w*c.Weight)
+				}
+			}
+		}
+		pick(0, known, 1)
+	} else {
+		var rnd rng.LCPRNG
+		rnd.Solo(true) // own generator, Dealer sequence is untouched
+		rnd.Randomize()
+		cum := make([][]float64, n) // cumulative weights
+		for i, rg := range live {
+			cum[i] = make([]float64, len(rg))
+			sum := 0.0
+			for j, c := range rg {
+				sum += c.Weight
+				cum[i][j] = sum
+			}
+		}
+		cards := bp.Inflate(bp.pack &^ known)
+		for t, miss := 0, 0; t < EquityTrials; t++ {
+			used := known
+			for i := 0; i < n; i++ { // rejection sampling of combos
+				if miss > 1000*1000 {
+					return odds, fmt.Errorf("equity: ranges hardly meet")
+				}
+				c := cum[i]
+				j := sort.SearchFloat64s(c, rnd.Random()*c[len(c)-1])
+				if j == len(c) {
+					j--
+				}
+				if h := live[i][j].Hold; h&used == 0 {
+					holds[i], used = h, used|h
+				} else {
+					i, used, miss = -1, known, miss+1 // start over
+				}
+			}
+			board, k := desk, len(cards)
+			for m := need; m > 0; { // partial Knuth shuffle
+				j := rnd.Choice(k)
+				k--
+				cards[j], cards[k] = cards[k], cards[j]
+				if b := bits(1) << cards[k]; b&used == 0 {
+					board |= b
+					m--
+				}
+			}
+			settle(board, 1)
+			miss = 0
+		}
+	}
+
+	if total == 0 {
+		return odds, fmt.Errorf("equity: ranges never meet")
+	}
+	odds.Equity = make([]Equity, n)
+	for i := range odds.Equity {
+		odds.Equity[i] = Equity{win[i] / total, tie[i] / total, 1 - (win[i]+tie[i])/total, share[i] / total}
+	}
+	return
+}
+
+// Ranges equity with ranges in standard notation and cards as "AsKh...".
+func (bp *BitPoker) RangeVersus(ranges []string, desk, dead string) (odds Odds, err error) {
+	rgs := make([]Range, len(ranges))
+	for i, text := range ranges {
+		if rgs[i], err = bp.ParseRange(text); err != nil {
+			return
+		}
+	}
+	var d, x bits
+	if d, err = bp.Parse(desk); err == nil {
+		if x, err = bp.Parse(dead); err == nil {
+			odds, err = bp.RangeEquity(rgs, d, x)
+		}
+	}
+	return
+}
+
+// Few well known range showdowns.
+func ShowRanges() {
+	var bp BitPoker
+	bp.Classic()
+	for _, game := range []struct {
+		ranges     []string
+		desk, dead string
+	}{
+		{[]string{"AsKs", "QQ+, AKs"}, "", ""},
+		{[]string{"AKo", "22-55, T9s-76s"}, "", ""},
+		{[]string{"QQ+, AKs, AKo:0.5", "JJ-99, AQs+, KQs:0.75"}, "Ts8h2c", ""},
+	} {
+		if odds, err := bp.RangeVersus(game.ranges, game.desk, game.dead); err != nil {
+			fmt.Println(err)
+		} else {
+			fmt.Println(game.ranges, game.desk)
+			odds.Print(game.ranges...)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"math"
+	"testing"
+)
+
+// Range notation to combos and weights.
+func TestParseRange(t *testing.T) {
+	var bp BitPoker
+	bp.Classic()
+	for _, c := range []struct {
+		text   string
+		combos int
+		weight float64
+	}{
+		{"AKs", 4, 4},
+		{"AKo", 12, 12},
+		{"AK", 16, 16},
+		{"QQ+", 18, 18},
+		{"ATs+", 16, 16},
+		{"T9s-76s", 16, 16},
+		{"22-55", 24, 24},
+		{"AsKh", 1, 1},
+		{"AKs:0.5", 4, 2},
+		{"AA, AsAh:0.5", 6, 5.5}, // repeated combo takes last weight
+	} {
+		rg, err := bp.ParseRange(c.text)
+		if err != nil {
+			t.Errorf("%q: %v", c.text, err)
+			continue
+		}
+		if len(rg) != c.combos || rg.Weight() != c.weight {
+			t.Errorf("%q: %d combos of weight %v, want %d of weight %v", c.text, len(rg), rg.Weight(), c.combos, c.weight)
+		}
+	}
+	for _, text := range []string{"AKx", "AAs", "AK:2", "AK:0", "AK-Q9", "As"} {
+		if _, err := bp.ParseRange(text); err == nil {
+			t.Errorf("%q: bad range accepted", text)
+		}
+	}
+}
+
+// Combos blocked by desk, dead cards or other player are excluded
+// and remaining combos count by weight.
+func TestRangeEquityBlocked(t *testing.T) {
+	var bp BitPoker
+	bp.Classic()
+	desk := parsePile(t, &bp, "2c7h9d")
+	queens := parsePile(t, &bp, "QdQc")
+	texas := func(hold string, dead bits) Equity {
+		odds, err := bp.TexasEquity([]bits{parsePile(t, &bp, hold), queens}, desk, dead)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return odds.Equity[0]
+	}
+	aces, kings := texas("AsAh", 0), texas("KsKh", 0)
+	turn, err := bp.TexasEquity([]bits{parsePile(t, &bp, "AsAh"), queens}, desk|parsePile(t, &bp, "Ks"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []struct {
+		name       string
+		hero       string
+		desk, dead string
+		want       float64
+	}{
+		{"dead card", "AsAh, KsKh", "2c7h9d", "Ks", texas("AsAh", parsePile(t, &bp, "Ks")).Share},
+		{"desk card", "AsAh, KsKh", "2c7h9dKs", "", turn.Equity[0].Share},
+		{"other player", "AsAh, QdQh", "2c7h9d", "", aces.Share},
+		{"weights", "AsAh:0.25, KsKh:0.75", "2c7h9d", "", 0.25*aces.Share + 0.75*kings.Share},
+	} {
+		odds, err := bp.RangeVersus([]string{c.hero, "QdQc"}, c.desk, c.dead)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !odds.Exact || math.Abs(odds.Equity[0].Share-c.want) > 1e-12 {
+			t.Errorf("%s: %q vs QdQc equity %.9f (exact %v), want %.9f", c.name, c.hero, odds.Equity[0].Share, odds.Exact, c.want)
+		}
+	}
+	if _, err := bp.RangeVersus([]string{"AsAh", "QdQc"}, "2c7h9d", "As"); err == nil {
+		t.Error("range blocked by dead card accepted")
+	}
+}
+
+// Monte Carlo agrees with exact enumeration within 4 standard errors.
+func TestRangeEquityMonteCarlo(t *testing.T) {
+	defer func(exact, trials int) {
+		EquityExact, EquityTrials = exact, trials
+	}(EquityExact, EquityTrials)
+	var bp BitPoker
+	bp.Classic()
+	for _, c := range []struct {
+		ranges []string
+		desk   string
+		share  float64 // exact equity of first player, 0 to enumerate
+	}{
+		{[]string{"AK, QQ", "JJ+, AQs:0.5"}, "Ts9s2d", 0},
+		{[]string{"AA", "KK"}, "", 0.8194605047}, // average of AsAh vs KdKc, KsKd (4 times) and KsKh
+	} {
+		p := c.share
+		if p == 0 {
+			EquityExact = 3 * 1000 * 1000
+			exact, err := bp.RangeVersus(c.ranges, c.desk, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !exact.Exact {
+				t.Fatalf("%v on %q: not enumerated", c.ranges, c.desk)
+			}
+			p = exact.Equity[0].Share
+		}
+		EquityExact, EquityTrials = 0, 200*1000
+		mc, err := bp.RangeVersus(c.ranges, c.desk, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d := 4 * math.Sqrt(p*(1-p)/float64(mc.Deals)); mc.Exact || math.Abs(mc.Equity[0].Share-p) > d {
+			t.Errorf("%v on %q: Monte Carlo equity %.5f, exact %.5f ± %.5f", c.ranges, c.desk, mc.Equity[0].Share, p, d)
+		}
+	}
+}
@@ -0,0 +1,448 @@
+package rng
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+)
+
+// # Random source under test.
+//
+// Satisfied by *LCPRNG, *XORshift and *Xaoc.
+type Source interface {
+	Choice(n int) int // random integer in range [0, n)
+	Deck() list       // shuffled deck of 52 cards
+}
+
+// # Statistical test verdict
+type Verdict struct {
+	Test string `json:"test"` // test name
+	Stat float  `json:"stat"` // test statistic (χ² or z)
+	DF   int    `json:"df"`   // degrees of freedom (0 for normal tests)
+	P    float  `json:"p"`    // p-value
+	Pass bool   `json:"pass"` // p ≥ ɑ
+}
+
+// # Statistical test battery (Diehard / NIST style)
+//
+// Every test draws numbers only through Source.Choice,
+// so any generator with the method can be certified.
+type Battery struct {
+	Title   string    // generator name
+	Alpha   float     // significance level, default 0.01
+	Sample  int       // base sample size, default 100000
+	Results []Verdict // verdicts of the last run
+	Elapsed float     // seconds of the last run
+}
+
+// # Upper tail of χ² distribution with k degrees of freedom.
+//
+//	p = Q(k / 2, x / 2)
+func ChiSquaredDist(x float, k int) float {
+	if k <= 0 {
+		return math.NaN()
+	}
+	return gammaQ(float(k)/2, x/2)
+}
+
+// # Regularized upper incomplete gamma function Q(a, x).
+//
+// Series for x < a + 1, Lentz continued fraction otherwise.
+func gammaQ(a, x float) float {
+	const (
+		ε    = 1e-15
+		tiny = 1e-300
+	)
+	if x <= 0 {
+		return 1
+	}
+	lg, _ := math.Lgamma(a)
+	f := math.Exp(a*math.Log(x) - x - lg)
+	if x < a+1 {
+		s, d := 1/a, 1/a
+		for n := a + 1; math.Abs(d) >= math.Abs(s)*ε; n++ {
+			d *= x / n
+			s += d
+		}
+		return math.Max(0, 1-s*f)
+	}
+	b := x + 1 - a
+	c, d := 1/tiny, 1/b
+	h := d
+	for i := 1.; i < 1000; i++ {
+		n := -i * (i - a)
+		b += 2
+		if d = n*d + b; math.Abs(d) < tiny {
+			d = tiny
+		}
+		if c = b + n/c; math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		e := d * c
+		if h *= e; math.Abs(e-1) < ε {
+			break
+		}
+	}
+	return h * f
+}
+
+// # Pearson χ² statistic of observed counts against expected probabilities.
+func chiSquared(count list, prob array) (χ2 float) {
+	n := 0
+	for _, c := range count {
+		n += c
+	}
+	for i, c := range count {
+		e := float(n) * prob[i]
+		d := float(c) - e
+		χ2 += d * d / e
+	}
+	return
+}
+
+// Add verdict of χ² test.
+func (b *Battery) chi(test string, count list, prob array) {
+	χ2, df := chiSquared(count, prob), len(count)-1
+	b.verdict(test, χ2, df, ChiSquaredDist(χ2, df))
+}
+
+// Add verdict.
+func (b *Battery) verdict(test string, stat float, df int, p float) {
+	b.Results = append(b.Results, Verdict{test, stat, df, p, p >= b.Alpha})
+}
+
+// Random bits taken 16 at a time.
+func (b *Battery) bits(src Source, n int) (e []byte) {
+	e = make([]byte, n)
+	for i := 0; i < n; i += 16 {
+		w := src.Choice(1 << 16)
+		for j := i; j < n && j < i+16; j, w = j+1, w>>1 {
+			e[j] = byte(w & 1)
+		}
+	}
+	return
+}
+
+// # Frequency (monobit) test, NIST SP 800-22 2.1.
+func (b *Battery) Frequency(src Source) {
+	n := b.Sample
+	s := 0
+	for _, e := range b.bits(src, n) {
+		s += 2*int(e) - 1
+	}
+	z := math.Abs(float(s)) / math.Sqrt(float(n))
+	b.verdict("frequency", z, 0, math.Erfc(z/math.Sqrt2))
+}
+
+// # Runs test, NIST SP 800-22 2.3.
+func (b *Battery) Runs(src Source) {
+	z, p := runsTest(b.bits(src, b.Sample))
+	b.verdict("runs", z, 0, p)
+}
+
+// Statistic and p-value of runs test for bits e.
+//
+//	p = erfc(|V - 2nπ(1 - π)| / (2√(2n) π(1 - π)))
+//
+// p = 0 if frequency prerequisite fails (statistic is then runs count).
+func runsTest(e []byte) (z, p float) {
+	n := len(e)
+	ones, runs := 0, 1
+	for i, x := range e {
+		ones += int(x)
+		if i > 0 && x != e[i-1] {
+			runs++
+		}
+	}
+	π := float(ones) / float(n)
+	if math.Abs(π-0.5) >= 2/math.Sqrt(float(n)) { // frequency prerequisite failed
+		return float(runs), 0
+	}
+	f := 2 * float(n) * π * (1 - π)
+	z = math.Abs(float(runs)-f) / (f * math.Sqrt(2/float(n))) // f·√(2/n) = 2√(2n)·π(1 - π)
+	return z, math.Erfc(z)
+}
+
+// # Serial test, TAOCP 2, 3.3.2 B (non-overlapping pairs of d = 16 values).
+func (b *Battery) Serial(src Source) {
+	const d = 16
+	count, prob := make(list, d*d), make(array, d*d)
+	for i := range prob {
+		prob[i] = 1. / (d * d)
+	}
+	for i := 0; i < b.Sample; i++ {
+		count[src.Choice(d)*d+src.Choice(d)]++
+	}
+	b.chi("serial", count, prob)
+}
+
+// # Gap test, TAOCP 2, 3.3.2 D (hits are values 0, 1, 2 of 10).
+func (b *Battery) Gap(src Source) {
+	const (
+		d = 10 // values
+		h = 3  // hits in [0, h)
+		t = 10 // longest gap category
+	)
+	p := float(h) / d
+	count, prob := make(list, t+1), make(array, t+1)
+	for r := range prob {
+		prob[r] = p * math.Pow(1-p, float(r))
+	}
+	prob[t] = math.Pow(1-p, t)
+	for gaps := 0; gaps < b.Sample/4; gaps++ {
+		r := 0
+		for src.Choice(d) >= h {
+			r++
+		}
+		count[imin(r, t)]++
+	}
+	b.chi("gap", count, prob)
+}
+
+// # Poker test, TAOCP 2, 3.3.2 C (groups of 5 values of 10, distinct values counted).
+func (b *Battery) Poker(src Source) {
+	const (
+		d = 10
+		k = 5
+	)
+	stirling := [k + 1]float{0, 1, 15, 25, 10, 1} // Stirling numbers of the 2nd kind S(5, r)
+	prob := make(array, k)
+	for r := 1; r <= k; r++ {
+		prob[r-1] = FallFact(d, r) * stirling[r] / math.Pow(d, k)
+	}
+	prob[1] += prob[0] // merge r = 1 into r = 2 (too rare)
+	prob = prob[1:]
+	count := make(list, k-1)
+	for g := 0; g < b.Sample/k; g++ {
+		m := 0
+		for i := 0; i < k; i++ {
+			m |= 1 << src.Choice(d)
+		}
+		r := 0
+		for ; m != 0; m &= m - 1 {
+			r++
+		}
+		count[imax(r, 2)-2]++
+	}
+	b.chi("poker", count, prob)
+}
+
+// # Birthday spacings test (Marsaglia), m = 512 birthdays in n = 2²⁴ days.
+//
+// Number of repeated spacings is Poisson with ƛ = m³ / 4n = 2.
+func (b *Battery) Birthday(src Source) {
+	const (
+		m = 512
+		n = 1 << 24
+		k = 5 // last category (k or more)
+	)
+	prob, rest := PoissonDist(k-1, float(m*m*m)/(4*n))
+	prob = append(prob, rest)
+	count := make(list, k+1)
+	days, gaps := make(list, m), make(list, m)
+	for t := 0; t < imax(b.Sample/500, 100); t++ {
+		for i := range days {
+			days[i] = src.Choice(n)
+		}
+		slices.Sort(days)
+		gaps[0] = days[0]
+		for i := 1; i < m; i++ {
+			gaps[i] = days[i] - days[i-1]
+		}
+		slices.Sort(gaps)
+		j := 0
+		for i := 1; i < m; i++ {
+			if gaps[i] == gaps[i-1] {
+				j++
+			}
+		}
+		count[imin(j, k)]++
+	}
+	b.chi("birthday spacings", count, prob)
+}
+
+// # Binary rank test, NIST SP 800-22 2.5 (32 × 32 matrices over GF(2)).
+func (b *Battery) Rank(src Source) {
+	const m = 32
+	rank := func(rows []uint32) (r int) {
+		for bit := uint32(1) << (m - 1); bit != 0; bit >>= 1 {
+			p := -1
+			for i := r; i < m; i++ {
+				if rows[i]&bit != 0 {
+					p = i
+					break
+				}
+			}
+			if p >= 0 {
+				rows[r], rows[p] = rows[p], rows[r]
+				for i := range rows {
+					if i != r && rows[i]&bit != 0 {
+						rows[i] ^= rows[r]
+					}
+				}
+				r++
+			}
+		}
+		return
+	}
+	prob := array{RankDist(m-1, m, m), RankDist(m, m, m)}
+	prob = append(array{1 - prob[0] - prob[1]}, prob...)
+	count := make(list, 3)
+	rows := make([]uint32, m)
+	for t := 0; t < imax(b.Sample/m, 100); t++ {
+		for i := range rows {
+			rows[i] = uint32(src.Choice(1<<16))<<16 | uint32(src.Choice(1<<16))
+		}
+		count[imax(rank(rows)-(m-2), 0)]++
+	}
+	b.chi("binary rank", count, prob)
+}
+
+// # Probability that random binary r × c matrix has rank k.
+func RankDist(k, r, c int) (prob float) {
+	if k < 0 || k > r || k > c {
+		return
+	}
+	prob = math.Pow(2, float(k*(r+c-k)-r*c))
+	for i := 0; i < k; i++ {
+		q := math.Pow(2, float(i))
+		prob *= (1 - q/math.Pow(2, float(r))) * (1 - q/math.Pow(2, float(c))) / (1 - q/math.Pow(2, float(k)))
+	}
+	return
+}
+
+// # χ² bias test of Choice(n) for typical game sizes.
+func (b *Battery) Bias(src Source, sizes ...int) {
+	if len(sizes) == 0 {
+		sizes = list{2, 3, 6, 10, 37, 52, 80, 1000}
+	}
+	for _, n := range sizes {
+		count, prob := make(list, n), make(array, n)
+		for i := range prob {
+			prob[i] = 1 / float(n)
+		}
+		for i := 0; i < imax(b.Sample, 10*n); i++ {
+			count[src.Choice(n)]++
+		}
+		b.chi(fmt.Sprintf("choice(%d) bias", n), count, prob)
+	}
+}
+
+// # Deck shuffle uniformity (position-by-card matrix).
+//
+// Every card must appear in every position with probability 1/52,
+// χ² with (52 - 1)² degrees of freedom.
+func (b *Battery) Shuffle(src Source) {
+	const n = 52
+	var matrix [n][n]int
+	decks := imax(b.Sample/20, 10*n)
+	for t := 0; t < decks; t++ {
+		for p, c := range src.Deck() {
+			matrix[p][c-1]++
+		}
+	}
+	e := float(decks) / n
+	χ2 := 0.
+	for _, row := range matrix {
+		for _, c := range row {
+			d := float(c) - e
+			χ2 += d * d / e
+		}
+	}
+	df := (n - 1) * (n - 1)
+	b.verdict("deck shuffle", χ2, df, ChiSquaredDist(χ2, df))
+}
+
+// # Run complete battery against source.
+func (b *Battery) Run(src Source) []Verdict {
+	if b.Alpha <= 0 {
+		b.Alpha = 0.01
+	}
+	if b.Sample <= 0 {
+		b.Sample = 100000
+	}
+	start := time.Now()
+	b.Results = nil
+	b.Frequency(src)
+	b.Runs(src)
+	b.Serial(src)
+	b.Gap(src)
+	b.Poker(src)
+	b.Birthday(src)
+	b.Rank(src)
+	b.Bias(src)
+	b.Shuffle(src)
+	b.Elapsed = time.Since(start).Seconds()
+	return b.Results
+}
+
+// # All tests passed?
+func (b *Battery) Passed() bool {
+	for _, v := range b.Results {
+		if !v.Pass {
+			return false
+		}
+	}
+	return len(b.Results) > 0
+}
+
+// # Print battery report.
+func (b *Battery) Report() {
+	fmt.Println()
+	fmt.Printf("%s  (ɑ = %v, sample = %d, t = %.3f\")\n", b.Title, b.Alpha, b.Sample, b.Elapsed)
+	fmt.Println("test                          statistic      df        p-value  verdict")
+	for _, v := range b.Results {
+		verdict := "pass"
+		if !v.Pass {
+			verdict = "FAIL"
+		}
+		fmt.Printf("%-24s  %13.4f  %6d  %13.9f  %s\n", v.Test, v.Stat, v.DF, v.P, verdict)
+	}
+}
+
+// # Certify generators used by the simulator.
+func Certify(sample int) (passed bool) {
+	var (
+		lcprng   LCPRNG
+		xorshift XORshift
+		xaoc     Xaoc
+	)
+	lcprng.Randomize()
+	xorshift.Randomize()
+	passed = true
+	for _, g := range []struct {
+		title string
+		src   Source
+		size  int
+	}{
+		{"LCPRNG", &lcprng, sample},
+		{"XORshift", &xorshift, sample},
+		{"Xaoc", &xaoc, sample / 10}, // too slow
+	} {
+		b := Battery{Title: g.title, Sample: g.size}
+		b.Run(g.src)
+		b.Report()
+		passed = passed && b.Passed()
+	}
+	return
+}
+
+// Smaller of two integers.
+func imin(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Larger of two integers.
+func imax(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
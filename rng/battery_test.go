@@ -0,0 +1,113 @@
+package rng
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"math"
+	"testing"
+)
+
+// Reference p-values of χ² upper tail, rank and Poisson distributions.
+func TestBatteryDistributions(t *testing.T) {
+	for _, c := range []struct {
+		name      string
+		got, want float
+		tol       float
+	}{
+		{"χ²(3.841459, 1)", ChiSquaredDist(3.841459, 1), 0.05, 1e-7},
+		{"χ²(6.634897, 1)", ChiSquaredDist(6.634897, 1), 0.01, 1e-7},
+		{"χ²(18.307038, 10)", ChiSquaredDist(18.307038, 10), 0.05, 1e-7},
+		{"χ²(135.806723, 100)", ChiSquaredDist(135.806723, 100), 0.01, 1e-7},
+		{"χ²(5, 2)", ChiSquaredDist(5, 2), math.Exp(-2.5), 1e-12}, // series and continued fraction
+		{"χ²(0.5, 2)", ChiSquaredDist(0.5, 2), math.Exp(-0.25), 1e-12},
+		{"rank 32 of 32 × 32", RankDist(32, 32, 32), 0.2887880950866, 1e-9}, // NIST SP 800-22 3.5 (m → ∞)
+		{"rank 31 of 32 × 32", RankDist(31, 32, 32), 0.5775761901732, 1e-9},
+		{"rank 1 of 1 × 1", RankDist(1, 1, 1), 0.5, 1e-15},
+	} {
+		if math.Abs(c.got-c.want) > c.tol {
+			t.Errorf("%s = %.13f, want %.13f", c.name, c.got, c.want)
+		}
+	}
+	prob, rest := PoissonDist(4, 2) // birthday spacings, ƛ = 2
+	if want := math.Exp(-2) * 5; math.Abs(prob[0]+prob[1]+prob[2]-want) > 1e-15 {
+		t.Errorf("Poisson(2) P(j ≤ 2) = %.15f, want %.15f", prob[0]+prob[1]+prob[2], want)
+	}
+	if want := 1 - math.Exp(-2)*5; math.Abs(prob[3]+prob[4]+rest-want) > 1e-15 {
+		t.Errorf("Poisson(2) P(j ≥ 3) = %.15f, want %.15f", prob[3]+prob[4]+rest, want)
+	}
+}
+
+// Battery on small sample of seeded generators.
+func TestBatteryRun(t *testing.T) {
+	var (
+		lcprng   LCPRNG
+		xorshift XORshift
+	)
+	lcprng.Randomize(2024)
+	xorshift.Randomize(2024)
+	for _, g := range []struct {
+		title string
+		src   Source
+	}{
+		{"LCPRNG", &lcprng},
+		{"XORshift", &xorshift},
+	} {
+		b := Battery{Title: g.title, Sample: 20000}
+		if len(b.Run(g.src)) != 16 {
+			t.Fatalf("%s: %d verdicts, want 16", g.title, len(b.Results))
+		}
+		for _, v := range b.Results {
+			if !(0 <= v.P && v.P <= 1) {
+				t.Errorf("%s: %s p-value %v out of [0, 1]", g.title, v.Test, v.P)
+			}
+		}
+		if !b.Passed() {
+			b.Report()
+			t.Errorf("%s: battery failed", g.title)
+		}
+	}
+}
+
+// Biased source must fail the battery.
+func TestBatteryBiased(t *testing.T) {
+	var rnd LCPRNG
+	rnd.Randomize(2024)
+	b := Battery{Title: "biased", Sample: 20000}
+	b.Run(biased{&rnd})
+	if b.Passed() {
+		t.Error("battery passed biased source")
+	}
+}
+
+// Source which never draws the highest value.
+type biased struct{ rnd *LCPRNG }
+
+func (s biased) Choice(n int) int {
+	if n > 1 {
+		n--
+	}
+	return s.rnd.Choice(n)
+}
+
+func (s biased) Deck() list {
+	return s.rnd.Deck()
+}
+
+// Runs test on worked examples of NIST SP 800-22 2.3.4 and 2.3.8.
+func TestBatteryRuns(t *testing.T) {
+	for _, c := range []struct {
+		bits string
+		p    float
+	}{
+		{"1001101011", 0.147232},
+		{"1100100100001111110110101010001000100001011010001100001000110100110001001100011001100010100010111000", 0.500798},
+	} {
+		e := make([]byte, len(c.bits))
+		for i := range e {
+			e[i] = c.bits[i] - '0'
+		}
+		if _, p := runsTest(e); math.Abs(p-c.p) > 1e-6 {
+			t.Errorf("%d bits: runs p-value %.6f, want %.6f", len(e), p, c.p)
+		}
+	}
+}
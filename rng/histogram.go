@@ -659,6 +659,7 @@ const (
 
 func init() {
 	// new(Histogram).StressTest(10000000)
+	// Certify(1000000)
 	// Slicke(728-658, 3)
 	// AlgP(2)
 	// kvadrilijarda untyped int = 1000 * kvadrilion // 1.000.000.000.000.000.000.000.000.000
@@ -4,6 +4,8 @@ package rng
 
 import (
 	"crypto/rand"
+	"encoding/binary"
+	"errors"
 	"math"
 	"math/big"
 	"slices"
@@ -16,6 +18,9 @@ import (
 // Cheap, fast and thread-safe rng for non-rgs stuff.
 var WSOGMM LCPRNG
 
+// Invalid serialized generator state.
+var ErrSeedLength = errors.New("rng: generator state must be 8 bytes")
+
 type ( // type aliases used in this module
 	octa  = uint64  // unsigned octabyte
 	list  = []int   // integers array
@@ -28,15 +33,58 @@ type ( // type aliases used in this module
 type LCPRNG struct {
 	seed octa       // generator seed
 	dog  sync.Mutex // watchdog Šarko
+	solo bool       // used by single goroutine, no watchdog
+}
+
+// # Unsynchronized generator for single goroutine.
+//
+// Seeded from this generator, which stays locked for shared use.
+func (rnd *LCPRNG) Local() *LCPRNG {
+	return &LCPRNG{seed: xorshift64(rnd.Next()), solo: true}
+}
+
+// # Switch watchdog off (solo) or on.
+//
+// Lock-free generator must not be shared between goroutines.
+func (rnd *LCPRNG) Solo(solo bool) {
+	rnd.solo = solo
 }
 
 // # Current seed.
 func (rnd *LCPRNG) Seed() octa {
+	if rnd.solo {
+		return rnd.seed
+	}
 	rnd.dog.Lock()
 	defer rnd.dog.Unlock()
 	return rnd.seed
 }
 
+// # Generator state as 8 bytes (encoding.BinaryMarshaler).
+func (rnd *LCPRNG) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, rnd.Seed())
+	return data, nil
+}
+
+// # Restore generator state (encoding.BinaryUnmarshaler).
+func (rnd *LCPRNG) UnmarshalBinary(data []byte) error {
+	if len(data) != 8 {
+		return ErrSeedLength
+	}
+	rnd.Restore(binary.BigEndian.Uint64(data))
+	return nil
+}
+
+// # Restore exact seed (from Seed or checkpoint).
+func (rnd *LCPRNG) Restore(seed octa) {
+	if !rnd.solo {
+		rnd.dog.Lock()
+		defer rnd.dog.Unlock()
+	}
+	rnd.seed = seed
+}
+
 // # Inititalize generator with seeds or system state.
 func (rnd *LCPRNG) Randomize(seeds ...octa) (seed octa) {
 	xorshift := func(x octa) octa { // by George Marsaglia
@@ -55,6 +103,10 @@ func (rnd *LCPRNG) Randomize(seeds ...octa) (seed octa) {
 			seed = xorshift(seed) ^ s
 		}
 	}
+	if rnd.solo {
+		rnd.seed = seed
+		return
+	}
 	rnd.dog.Lock()         // Meni je nekako logičnije
 	defer rnd.dog.Unlock() // da bude obrnuto, ... :)
 	rnd.seed = seed
@@ -72,14 +124,18 @@ where constants
 are given by Knuth in MMIX RISC processor.
 */
 func (rnd *LCPRNG) Next() octa {
-	rnd.dog.Lock()         // ... da pustim kuče dok ja radim,
-	defer rnd.dog.Unlock() // a da ga vežem kad rade drugi. :)
 	const (
 		a octa = 0x5851f42d4c957f2d // multiplier
 		c octa = 0x14057b7ef767814f // incrementer
 	)
-	rnd.seed *= a // constants
-	rnd.seed += c // by Knuth
+	if rnd.solo {
+		rnd.seed = rnd.seed*a + c
+		return rnd.seed
+	}
+	rnd.dog.Lock()         // ... da pustim kuče dok ja radim,
+	defer rnd.dog.Unlock() // a da ga vežem kad rade drugi. :)
+	rnd.seed *= a          // constants
+	rnd.seed += c          // by Knuth
 	return rnd.seed
 }
 
@@ -100,17 +156,77 @@ For given constants a and c in Next method
 	d = 0x9995b5b621535015
 */
 func (rnd *LCPRNG) Prev() octa {
-	rnd.dog.Lock()
-	defer rnd.dog.Unlock()
 	const (
 		b octa = 0xc097ef87329e28a5 // multiplier
 		d octa = 0x9995b5b621535015 // incrementer
 	)
+	if rnd.solo {
+		rnd.seed = rnd.seed*b + d
+		return rnd.seed
+	}
+	rnd.dog.Lock()
+	defer rnd.dog.Unlock()
 	rnd.seed *= b
 	rnd.seed += d
 	return rnd.seed
 }
 
+// # Skip n steps of generator in O(log n).
+/*
+Next is affine map f(x) = a * x + c, so n-fold composition is
+	fⁿ(x) = aⁿ * x + c * (aⁿ⁻¹ + ··· + a + 1)
+calculated by repeated squaring
+	f²(x) = a² * x + c * (a + 1)
+Since period is 2⁶⁴, Jump(-octa(n)) goes n steps back (Jump(^octa(0)) = Prev).
+*/
+func (rnd *LCPRNG) Jump(n octa) octa {
+	if rnd.solo {
+		rnd.seed = jump(rnd.seed, n)
+		return rnd.seed
+	}
+	rnd.dog.Lock()
+	defer rnd.dog.Unlock()
+	rnd.seed = jump(rnd.seed, n)
+	return rnd.seed
+}
+
+// Affine power composition of Next.
+func jump(seed, n octa) octa {
+	const (
+		a octa = 0x5851f42d4c957f2d // multiplier
+		c octa = 0x14057b7ef767814f // incrementer
+	)
+	A, C := octa(1), octa(0) // identity
+	for b, d := a, c; n != 0; n >>= 1 {
+		if n&1 != 0 {
+			A, C = A*b, C*b+d
+		}
+		b, d = b*b, d*(b+1)
+	}
+	return A*seed + C
+}
+
+// # Split generator into k non-overlapping substreams.
+//
+// Sequence is cut into k + 1 segments of span = ⌊2⁶⁴ / (k + 1)⌋ steps.
+// Generator keeps first segment and substream i starts (i + 1) · span
+// steps ahead of current seed, so generator and k parallel workers
+// can draw up to span randoms each from disjoint segments of one
+// seeded sequence. Substreams are lock-free (one per worker goroutine).
+func (rnd *LCPRNG) Split(k int) (streams []*LCPRNG) {
+	if k > 0 {
+		n := octa(k) + 1
+		span := -n/n + 1 // 2⁶⁴ / n
+		seed := rnd.Seed()
+		streams = make([]*LCPRNG, k)
+		for i := range streams {
+			seed = jump(seed, span)
+			streams[i] = &LCPRNG{seed: seed, solo: true}
+		}
+	}
+	return
+}
+
 // # Next random value from generator limited to range [0, n].
 //
 //	μ  = n / 2
@@ -0,0 +1,85 @@
+package rng
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"math/bits"
+	"testing"
+)
+
+// Locked (shared) and lock-free (solo) LCPRNG.
+func BenchmarkLCPRNG(b *testing.B) {
+	for _, solo := range []bool{false, true} {
+		name := map[bool]string{false: "locked", true: "solo"}[solo]
+		b.Run(name, func(b *testing.B) {
+			var rnd LCPRNG
+			rnd.Solo(solo)
+			rnd.Randomize(2024)
+			for i := 0; i < b.N; i++ {
+				rnd.Next()
+			}
+		})
+	}
+}
+
+// Locked (shared) and lock-free (solo) XORshift.
+func BenchmarkXORshift(b *testing.B) {
+	for _, solo := range []bool{false, true} {
+		name := map[bool]string{false: "locked", true: "solo"}[solo]
+		b.Run(name, func(b *testing.B) {
+			var rnd XORshift
+			rnd.Solo(solo)
+			rnd.Randomize(2024)
+			for i := 0; i < b.N; i++ {
+				rnd.Next()
+			}
+		})
+	}
+}
+
+// Jump(n) equals n calls of Next and Jump(^0) equals Prev.
+func TestLCPRNGJump(t *testing.T) {
+	var a, b LCPRNG
+	a.Randomize(2024)
+	b.Randomize(2024)
+	for _, n := range []int{0, 1, 2, 7, 1000} {
+		for i := 0; i < n; i++ {
+			a.Next()
+		}
+		if x, y := a.Seed(), b.Jump(octa(n)); x != y {
+			t.Fatalf("Jump(%d) = %#x, %d calls of Next = %#x", n, y, n, x)
+		}
+	}
+	if x, y := a.Prev(), b.Jump(^octa(0)); x != y {
+		t.Fatalf("Jump(^0) = %#x, Prev = %#x", y, x)
+	}
+	back := octa(5)
+	b.Jump(-back)
+	if x, y := a.Next(), b.Jump(back+1); x != y {
+		t.Fatalf("Jump 5 back and 6 forth = %#x, Next = %#x", y, x)
+	}
+}
+
+// Split substreams start span steps apart, after generator segment.
+func TestLCPRNGSplit(t *testing.T) {
+	for _, k := range []int{1, 2, 3, 7, 8} {
+		var rnd LCPRNG
+		seed := rnd.Randomize(2024)
+		span, _ := bits.Div64(1, 0, octa(k+1)) // ⌊2⁶⁴ / (k + 1)⌋
+		streams := rnd.Split(k)
+		if len(streams) != k {
+			t.Fatalf("Split(%d) returned %d streams", k, len(streams))
+		}
+		if rnd.Seed() != seed {
+			t.Fatalf("Split(%d) moved generator", k)
+		}
+		for i, s := range streams {
+			if want := jump(seed, octa(i+1)*span); s.Seed() != want {
+				t.Fatalf("Split(%d) stream %d seed %#x, want %#x", k, i, s.Seed(), want)
+			}
+			if s.Seed() == seed {
+				t.Fatalf("Split(%d) stream %d repeats generator", k, i)
+			}
+		}
+	}
+}
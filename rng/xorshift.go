@@ -4,6 +4,7 @@ package rng
 
 import (
 	"crypto/rand"
+	"encoding/binary"
 	"math"
 	"math/big"
 	"sync"
@@ -14,6 +15,7 @@ import (
 type XORshift struct {
 	seed octa       // generator seed
 	dog  sync.Mutex // watchdog Šarko
+	solo bool       // used by single goroutine, no watchdog
 }
 
 // George Marsaglia shift-register generator.
@@ -24,13 +26,69 @@ func xorshift64(x octa) octa {
 	return x
 }
 
+// SplitMix64 finalizer (Steele, Lea, Flood), bijective 64-bit mixer.
+func splitmix64(x octa) octa {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ x>>30) * 0xbf58476d1ce4e5b9
+	x = (x ^ x>>27) * 0x94d049bb133111eb
+	return x ^ x>>31
+}
+
+// # Unsynchronized generator for single goroutine.
+//
+// Seeded from this generator, which stays locked for shared use.
+// Seed is mixed by SplitMix64, so child does not replay parent sequence
+// (xorshift64 of parent output would be its next output).
+func (rnd *XORshift) Local() *XORshift {
+	seed := splitmix64(rnd.Next())
+	for seed == 0 {
+		seed = splitmix64(rnd.Next())
+	}
+	return &XORshift{seed: seed, solo: true}
+}
+
+// # Switch watchdog off (solo) or on.
+//
+// Lock-free generator must not be shared between goroutines.
+func (rnd *XORshift) Solo(solo bool) {
+	rnd.solo = solo
+}
+
 // # Current seed.
 func (rnd *XORshift) Seed() octa {
+	if rnd.solo {
+		return rnd.seed
+	}
 	rnd.dog.Lock()
 	defer rnd.dog.Unlock()
 	return rnd.seed
 }
 
+// # Generator state as 8 bytes (encoding.BinaryMarshaler).
+func (rnd *XORshift) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, rnd.Seed())
+	return data, nil
+}
+
+// # Restore generator state (encoding.BinaryUnmarshaler).
+func (rnd *XORshift) UnmarshalBinary(data []byte) error {
+	if len(data) != 8 {
+		return ErrSeedLength
+	}
+	rnd.Restore(binary.BigEndian.Uint64(data))
+	return nil
+}
+
+// # Restore exact seed (from Seed or checkpoint).
+func (rnd *XORshift) Restore(seed octa) {
+	if !rnd.solo {
+		rnd.dog.Lock()
+		defer rnd.dog.Unlock()
+	}
+	rnd.seed = seed
+}
+
 // # Inititalize generator with seeds or system state.
 func (rnd *XORshift) Randomize(seeds ...octa) (seed octa) {
 	if len(seeds) == 0 {
@@ -40,6 +98,10 @@ func (rnd *XORshift) Randomize(seeds ...octa) (seed octa) {
 			seed = xorshift64(seed) ^ s
 		}
 	}
+	if rnd.solo {
+		rnd.seed = seed
+		return
+	}
 	rnd.dog.Lock()         // Meni je nekako logičnije
 	defer rnd.dog.Unlock() // da bude obrnuto, ... :)
 	rnd.seed = seed
@@ -48,8 +110,10 @@ func (rnd *XORshift) Randomize(seeds ...octa) (seed octa) {
 
 // # Next random value from generator.
 func (rnd *XORshift) Next() octa {
-	rnd.dog.Lock()         // ... da pustim kuče dok ja radim,
-	defer rnd.dog.Unlock() // a da ga vežem kad rade drugi. :)
+	if !rnd.solo {
+		rnd.dog.Lock()         // ... da pustim kuče dok ja radim,
+		defer rnd.dog.Unlock() // a da ga vežem kad rade drugi. :)
+	}
 	rnd.seed = xorshift64(rnd.seed)
 	for rnd.seed == 0 {
 		rnd.seed = octa(time.Now().UnixNano())
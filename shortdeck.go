@@ -0,0 +1,60 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"fmt"
+)
+
+// Short deck (6+) Holdem with 36 cards.
+//
+// Flush beats full house, A9876 is straight if wheel is set
+// and 3 of kind beats straight if trips is set.
+/*
+	pack = 0xFFFFFFFFF0000, wheel (A9876)
+	9        4  royal flush
+	8       20  straight flush
+	7      288  4 of kind
+	5      480  flush
+	6     1728  full house
+	4     6120  straight
+	3    16128  3 of kind
+	2    36288  2 pair
+	1   193536  1 pair
+	0   122400  high card
+	Σ   376992  total
+*/
+// Without wheel counts are the same as in SixUp.
+func (bp *BitPoker) ShortDeck(wheel, trips bool) {
+	const pack = standard_pack >> 16 << 16
+	order := []int{0, 1, 2, 3, 4, 6, 5, 7, 8, 9} // flush above full house
+	if trips {
+		order[3], order[4] = 4, 3 // 3 of kind above straight
+	}
+	bp.Init(pack, wheel, order)
+}
+
+// Short deck (6+) Holdem with 36 cards.
+func (pok *Poker) ShortDeck(wheel, trips bool) {
+	pok.bp.ShortDeck(wheel, trips)
+}
+
+// Print hands counts of short deck variants in hands order.
+func ShowShortDeck() {
+	for _, wheel := range []bool{false, true} {
+		for _, trips := range []bool{false, true} {
+			var bp BitPoker
+			bp.ShortDeck(wheel, trips)
+			var count [10]int
+			var s sampler
+			for s.Init(bp.pack, 5); !s.Eof(); {
+				count[bp.Category(bp.Rank(s.Next()))]++
+			}
+			fmt.Printf("wheel %-5v  trips %-5v  %d ranks\n", wheel, trips, bp.Ranks())
+			for p := 9; p >= 0; p-- {
+				h := bp.order[p]
+				fmt.Printf("\t%d  %7d  %s\n", h, count[h], bp.Names[h])
+			}
+		}
+	}
+}
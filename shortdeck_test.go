@@ -0,0 +1,44 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import "testing"
+
+// Short deck variants: hands counts (of doc comments), rank tables
+// against reference evaluator and ranks of hands in order never overlap.
+func TestShortDeck(t *testing.T) {
+	counts := map[bool][10]int{ // without and with wheel
+		false: {123420, 193536, 36288, 16128, 5100, 484, 1728, 288, 16, 4},
+		true:  {122400, 193536, 36288, 16128, 6120, 480, 1728, 288, 20, 4},
+	}
+	for _, c := range []struct{ wheel, trips bool }{{false, false}, {false, true}, {true, false}, {true, true}} {
+		var bp BitPoker
+		bp.ShortDeck(c.wheel, c.trips)
+
+		var count [10]int
+		low, high := [10]int{}, [10]int{} // ranks range by hand
+		var s sampler
+		for s.Init(bp.pack, 5); !s.Eof(); {
+			h := s.Next()
+			code := bp.handCode(h)
+			if a := bp.Hand(h); a != code {
+				t.Fatalf("wheel %v trips %v: %x rank %q, reference %q", c.wheel, c.trips, h, a, code)
+			}
+			hand, r := number(code[7]), bp.Rank(h)
+			if count[hand]++; low[hand] == 0 || r < low[hand] {
+				low[hand] = r
+			}
+			if r > high[hand] {
+				high[hand] = r
+			}
+		}
+		if count != counts[c.wheel] {
+			t.Errorf("wheel %v trips %v: counts %v, want %v", c.wheel, c.trips, count, counts[c.wheel])
+		}
+		for p := 1; p < 10; p++ {
+			if a, b := bp.order[p-1], bp.order[p]; high[a] >= low[b] {
+				t.Errorf("wheel %v trips %v: %s not above %s", c.wheel, c.trips, bp.Names[b], bp.Names[a])
+			}
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"DHSimulator/rng"
+	"fmt"
+)
+
+const ( // Sic Bo bet kinds
+	SicBoSmall     = iota // total 4-10, not triple
+	SicBoBig              // total 11-17, not triple
+	SicBoTotal            // total Number (4-17)
+	SicBoTriple           // triple of Number
+	SicBoAnyTriple        // any triple
+	SicBoDouble           // double of Number
+	SicBoCombo            // Number and Second on two dice
+	SicBoSingle           // Number on one, two or three dice
+)
+
+// Sic Bo bet on layout.
+type SicBoBet struct {
+	Kind   int     `json:"kind"`   // bet kind
+	Number int     `json:"number"` // total or die number
+	Second int     `json:"second"` // second die number of combination
+	Stake  float64 `json:"stake"`  // bet amount
+}
+
+// Sic Bo paytable, pays are "to 1".
+type SicBoPays struct {
+	Small     float64   `json:"small"`      // small
+	Big       float64   `json:"big"`        // big
+	Totals    []float64 `json:"totals"`     // totals 4-17 by total (index)
+	Triple    float64   `json:"triple"`     // specific triple
+	AnyTriple float64   `json:"any_triple"` // any triple
+	Double    float64   `json:"double"`     // specific double
+	Combo     float64   `json:"combo"`      // two dice combination
+	Singles   []float64 `json:"singles"`    // single number by dice count (index)
+}
+
+// Macau paytable (Small and Big lose on triples).
+var MacauSicBo = SicBoPays{
+	Small: 1, Big: 1,
+	Totals: []float64{0, 0, 0, 0, 60, 30, 17, 12, 8, 6, 6, 6, 6, 8, 12, 17, 30, 60},
+	Triple: 180, AnyTriple: 30, Double: 10, Combo: 5,
+	Singles: []float64{0, 1, 2, 3},
+}
+
+// # Sic Bo
+//
+// Three dice are rolled, bets of layout win by dice combination.
+type SicBo struct {
+	Pays  SicBoPays  `json:"pays"`  // paytable
+	Stats Tally      `json:"stats"` // session statistics
+	rnd   rng.LCPRNG // dice roller
+}
+
+// Initialize dice roller.
+func (sb *SicBo) Init(seeds ...uint64) {
+	sb.rnd.Solo(true) // game is used by single goroutine
+	sb.rnd.Randomize(seeds...)
+}
+
+// All 50 bets of layout (stake 1).
+func SicBoLayout() (bets []SicBoBet) {
+	bets = append(bets, SicBoBet{Kind: SicBoSmall, Stake: 1}, SicBoBet{Kind: SicBoBig, Stake: 1})
+	for t := 4; t <= 17; t++ {
+		bets = append(bets, SicBoBet{Kind: SicBoTotal, Number: t, Stake: 1})
+	}
+	for n := 1; n <= 6; n++ {
+		bets = append(bets, SicBoBet{Kind: SicBoTriple, Number: n, Stake: 1})
+	}
+	bets = append(bets, SicBoBet{Kind: SicBoAnyTriple, Stake: 1})
+	for n := 1; n <= 6; n++ {
+		bets = append(bets, SicBoBet{Kind: SicBoDouble, Number: n, Stake: 1})
+	}
+	for n := 1; n <= 6; n++ {
+		for m := n + 1; m <= 6; m++ {
+			bets = append(bets, SicBoBet{Kind: SicBoCombo, Number: n, Second: m, Stake: 1})
+		}
+	}
+	for n := 1; n <= 6; n++ {
+		bets = append(bets, SicBoBet{Kind: SicBoSingle, Number: n, Stake: 1})
+	}
+	return
+}
+
+// Bet name.
+func (bet SicBoBet) String() string {
+	switch bet.Kind {
+	case SicBoSmall:
+		return "small"
+	case SicBoBig:
+		return "big"
+	case SicBoTotal:
+		return fmt.Sprintf("total %d", bet.Number)
+	case SicBoTriple:
+		return fmt.Sprintf("triple %d", bet.Number)
+	case SicBoAnyTriple:
+		return "any triple"
+	case SicBoDouble:
+		return fmt.Sprintf("double %d", bet.Number)
+	case SicBoCombo:
+		return fmt.Sprintf("combo %d-%d", bet.Number, bet.Second)
+	case SicBoSingle:
+		return fmt.Sprintf("single %d", bet.Number)
+	}
+	return "unknown"
+}
+
+// Paytable odds of bet "to 1" (single number for one die).
+func (p *SicBoPays) Odds(bet SicBoBet) float64 {
+	switch bet.Kind {
+	case SicBoSmall:
+		return p.Small
+	case SicBoBig:
+		return p.Big
+	case SicBoTotal:
+		if bet.Number >= 0 && bet.Number < len(p.Totals) {
+			return p.Totals[bet.Number]
+		}
+	case SicBoTriple:
+		return p.Triple
+	case SicBoAnyTriple:
+		return p.AnyTriple
+	case SicBoDouble:
+		return p.Double
+	case SicBoCombo:
+		return p.Combo
+	case SicBoSingle:
+		if len(p.Singles) > 1 {
+			return p.Singles[1]
+		}
+	}
+	return 0
+}
+
+// Payout of bet per stake "to 1" for dice, -1 if bet loses.
+func (p *SicBoPays) Pay(bet SicBoBet, dice []int) float64 {
+	total, count, triple := 0, [7]int{}, false
+	for _, d := range dice {
+		total += d
+		count[d]++
+		triple = triple || count[d] == 3
+	}
+	die := func(n int) bool { return n >= 1 && n <= 6 }
+	win, pay := false, p.Odds(bet)
+	switch bet.Kind {
+	case SicBoSmall:
+		win = !triple && total >= 4 && total <= 10
+	case SicBoBig:
+		win = !triple && total >= 11 && total <= 17
+	case SicBoTotal:
+		win = total == bet.Number
+	case SicBoTriple:
+		win = die(bet.Number) && count[bet.Number] == 3
+	case SicBoAnyTriple:
+		win = triple
+	case SicBoDouble:
+		win = die(bet.Number) && count[bet.Number] >= 2
+	case SicBoCombo:
+		win = die(bet.Number) && die(bet.Second) && bet.Number != bet.Second && count[bet.Number] > 0 && count[bet.Second] > 0
+	case SicBoSingle:
+		if die(bet.Number) && count[bet.Number] > 0 && count[bet.Number] < len(p.Singles) {
+			win, pay = true, p.Singles[count[bet.Number]]
+		}
+	}
+	if !win || pay <= 0 {
+		return -1
+	}
+	return pay
+}
+
+// Exact win probability, return to player and house edge of bet.
+//
+// All 56 dice combinations (Ludus Clericalis) are weighted by their
+// probabilities (1, 3 or 6 of 216 rolls):
+/*
+	rtp = Σ prob(c) · (pay(c) + 1)
+	edge = 1 - rtp
+*/
+func (p *SicBoPays) Exact(bet SicBoBet) (win, rtp, edge float64) {
+	for a := 1; a <= 6; a++ {
+		for b := a; b <= 6; b++ {
+			for c := b; c <= 6; c++ {
+				_, _, prob := rng.Ludus(6, a, b, c)
+				if pay := p.Pay(bet, []int{a, b, c}); pay > 0 {
+					win += prob
+					rtp += prob * (pay + 1)
+				}
+			}
+		}
+	}
+	return win, rtp, 1 - rtp
+}
+
+// Print exact analysis of layout.
+func (p *SicBoPays) Print() {
+	fmt.Println("bet                  pays     probability        rtp    house edge")
+	for _, bet := range SicBoLayout() {
+		win, rtp, edge := p.Exact(bet)
+		odds := fmt.Sprintf("%g:1", p.Odds(bet))
+		if bet.Kind == SicBoSingle {
+			odds = fmt.Sprint(p.Singles[1:])
+		}
+		fmt.Printf("%-16s  %8s  %11.7f%%  %9.5f%%  %9.5f%%\n", bet, odds, 100*win, 100*rtp, 100*edge)
+	}
+	fmt.Println()
+}
+
+// Play session of rounds with same bets and show report.
+func (sb *SicBo) Session(rounds int, bets []SicBoBet) {
+	st := &sb.Stats
+	for _, b := range bets {
+		st.Order = append(st.Order, b.String())
+	}
+	st.Order = append(st.Order, "-", "total")
+	for i := 0; i < rounds; i++ {
+		dice, _, _ := sb.rnd.SicBo()
+		bet, win := 0., 0.
+		for _, b := range bets {
+			bet += b.Stake
+			if pay := sb.Pays.Pay(b, dice); pay > 0 {
+				st.Add(b.String(), b.Stake*(pay+1))
+				win += b.Stake * (pay + 1)
+			}
+		}
+		st.Play(bet, win)
+	}
+	st.Report("Sic Bo")
+}
+
+// Exact analysis of Macau paytable and session of few bets.
+func ShowSicBo(rounds int) {
+	pays := MacauSicBo
+	pays.Print()
+	sb := SicBo{Pays: pays}
+	sb.Init(2024)
+	sb.Session(rounds, []SicBoBet{
+		{Kind: SicBoSmall, Stake: 10},
+		{Kind: SicBoTotal, Number: 9, Stake: 2},
+		{Kind: SicBoDouble, Number: 3, Stake: 1},
+		{Kind: SicBoCombo, Number: 2, Second: 5, Stake: 2},
+		{Kind: SicBoSingle, Number: 6, Stake: 5},
+		{Kind: SicBoAnyTriple, Stake: 1},
+	})
+}
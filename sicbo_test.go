@@ -0,0 +1,57 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"math"
+	"testing"
+)
+
+// House edges of Macau paytable against closed forms of 216 rolls.
+func TestSicBoEdges(t *testing.T) {
+	for _, c := range []struct {
+		bet  SicBoBet
+		edge float64
+	}{
+		{SicBoBet{Kind: SicBoSmall}, 6. / 216},              // 2.78%, wins 105 of 216
+		{SicBoBet{Kind: SicBoBig}, 6. / 216},                // 2.78%
+		{SicBoBet{Kind: SicBoTotal, Number: 4}, 33. / 216},  // 3 rolls at 60 to 1
+		{SicBoBet{Kind: SicBoTotal, Number: 10}, 27. / 216}, // 27 rolls at 6 to 1
+		{SicBoBet{Kind: SicBoTriple, Number: 5}, 35. / 216}, // 1 roll at 180 to 1
+		{SicBoBet{Kind: SicBoAnyTriple}, 30. / 216},         // 6 rolls at 30 to 1
+		{SicBoBet{Kind: SicBoDouble, Number: 2}, 40. / 216}, // 16 rolls at 10 to 1
+		{SicBoBet{Kind: SicBoCombo, Number: 1, Second: 6}, 36. / 216},
+		{SicBoBet{Kind: SicBoSingle, Number: 3}, 17. / 216}, // 75, 15 and 1 rolls at 1, 2 and 3 to 1
+	} {
+		if _, _, edge := MacauSicBo.Exact(c.bet); math.Abs(edge-c.edge) > 1e-12 {
+			t.Errorf("%s: house edge %.6f%%, want %.6f%%", c.bet, 100*edge, 100*c.edge)
+		}
+	}
+}
+
+// Pays of fixed rolls.
+func TestSicBoPay(t *testing.T) {
+	for _, c := range []struct {
+		bet  SicBoBet
+		dice []int
+		pay  float64
+	}{
+		{SicBoBet{Kind: SicBoSmall}, []int{1, 3, 6}, 1},
+		{SicBoBet{Kind: SicBoSmall}, []int{2, 2, 2}, -1}, // triple loses
+		{SicBoBet{Kind: SicBoBig}, []int{5, 5, 5}, -1},
+		{SicBoBet{Kind: SicBoTotal, Number: 17}, []int{5, 6, 6}, 60},
+		{SicBoBet{Kind: SicBoAnyTriple}, []int{4, 4, 4}, 30},
+		{SicBoBet{Kind: SicBoDouble, Number: 4}, []int{4, 4, 4}, 10},
+		{SicBoBet{Kind: SicBoCombo, Number: 2, Second: 5}, []int{5, 1, 2}, 5},
+		{SicBoBet{Kind: SicBoCombo, Number: 2, Second: 5}, []int{2, 2, 3}, -1},
+		{SicBoBet{Kind: SicBoSingle, Number: 6}, []int{6, 1, 6}, 2},
+		{SicBoBet{Kind: SicBoSingle, Number: 6}, []int{1, 2, 3}, -1},
+	} {
+		if pay := MacauSicBo.Pay(c.bet, c.dice); pay != c.pay {
+			t.Errorf("%s on %v: pay %v, want %v", c.bet, c.dice, pay, c.pay)
+		}
+	}
+	if n := len(SicBoLayout()); n != 50 {
+		t.Errorf("layout has %d bets, want 50", n)
+	}
+}
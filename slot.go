@@ -0,0 +1,681 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"DHSimulator/rng"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// No symbol (game without wild or scatter).
+const NoSymbol symbol = math.MinInt32
+
+// Line wins of symbol by count of consecutive reels (index is count).
+type Paytable map[symbol][]float64
+
+// # Slot machine math
+//
+// Reel strips spin to random stops, window of each reel shows height
+// symbols from stop down (wrapping around strip). Symbols on consecutive
+// reels pay all ways and along paylines (either or both), wilds substitute
+// all but scatter. Order is as in WaysItemNew: 1 from left, -1 from right
+// and 0 both ways (wins on all reels paid once).
+// Scatters pay anywhere on window. All pays are in total bets.
+//
+// Wild is plain wild (NoSymbol for none), Wilds are its variants. Wins
+// of wilds only are paid as first of them. Expanding wilds cover their
+// reels before evaluation, sticky wilds stay on window while held.
+//
+// Clusters of Cluster or more cells pay as alternative (or addition) to
+// ways and lines. Cascading reels replace winning cells until no win.
+// Features (free spins, pick bonus) are triggered by scatters.
+type Slot struct {
+	Name        string            `json:"name"`            // game name
+	Reels       [][]symbol        `json:"reels"`           // reel strips
+	Height      []int             `json:"height"`          // window heights by reel (cycled)
+	Wild        symbol            `json:"wild"`            // wild symbol
+	Wilds       []Wild            `json:"wilds,omitempty"` // wild variants
+	Scatter     symbol            `json:"scatter"`         // scatter symbol
+	Order       int               `json:"order"`           // pays order
+	Pays        Paytable          `json:"pays"`            // ways pays
+	Lines       [][]int           `json:"lines,omitempty"` // paylines, window row by reel
+	LinePays    Paytable          `json:"line_pays"`       // paylines pays
+	Cluster     int               `json:"cluster"`         // min cluster size, 0 for no cluster pays
+	ClusterPays Paytable          `json:"cluster_pays"`    // cluster pays by size
+	Cascade     bool              `json:"cascade"`         // cascading reels
+	Multipliers []float64         `json:"multipliers"`     // cascade multipliers by step (last repeats)
+	Scatters    []float64         `json:"scatters"`        // scatter pays by count
+	Features    []Feature         `json:"features"`        // features triggered by scatters
+	Names       map[symbol]string `json:"names,omitempty"` // symbol names
+	Stats       Tally             `json:"stats"`           // simulation statistics
+	rnd         rng.LCPRNG        // reels spinner
+	sticky      [][]symbol        // held sticky wilds by reel (NoSymbol if none), nil if not held
+}
+
+// Result of single spin.
+type Spin struct {
+	Stops   []int         `json:"stops"`           // reel stops
+	Grid    [][]symbol    `json:"grid"`            // window symbols by reel
+	Wins    []WaysItemNew `json:"wins"`            // paying ways, lines and clusters
+	Scatter int           `json:"scatter"`         // scatters on window
+	Bonus   float64       `json:"bonus"`           // scatter win
+	Win     float64       `json:"win"`             // total win
+	Multi   float64       `json:"multi,omitempty"` // cascade step multiplier
+	Steps   []Spin        `json:"steps,omitempty"` // cascade steps (first is spin itself)
+}
+
+// Check game and seed spinner (same seeds, same spins) or system state.
+func (sl *Slot) Init(seeds ...uint64) error {
+	if len(sl.Reels) == 0 {
+		return fmt.Errorf("slot %s: no reels", sl.Name)
+	}
+	for i, r := range sl.Reels {
+		if len(r) == 0 {
+			return fmt.Errorf("slot %s: reel %d is empty", sl.Name, i+1)
+		}
+	}
+	for _, h := range sl.Height {
+		if h <= 0 {
+			return fmt.Errorf("slot %s: bad height %d", sl.Name, h)
+		}
+	}
+	for l, rows := range sl.Lines {
+		if len(rows) != len(sl.Reels) {
+			return fmt.Errorf("slot %s: payline %d has %d rows", sl.Name, l+1, len(rows))
+		}
+		for i, r := range rows {
+			if r < 0 || r >= sl.height(i) {
+				return fmt.Errorf("slot %s: payline %d out of window on reel %d", sl.Name, l+1, i+1)
+			}
+		}
+	}
+	for _, f := range sl.Features {
+		if f.Reels != nil && len(f.Reels) != len(sl.Reels) {
+			return fmt.Errorf("slot %s: feature %s has %d reels", sl.Name, f.Name, len(f.Reels))
+		}
+		for i, r := range f.Reels {
+			if len(r) == 0 {
+				return fmt.Errorf("slot %s: feature %s reel %d is empty", sl.Name, f.Name, i+1)
+			}
+		}
+		if len(f.Weights) > len(f.Prizes) {
+			return fmt.Errorf("slot %s: feature %s has %d weights for %d prizes", sl.Name, f.Name, len(f.Weights), len(f.Prizes))
+		}
+		picks, mass := 0, 0
+		for _, p := range f.Picks {
+			picks += p
+		}
+		for _, w := range f.Weights {
+			if w < 0 {
+				return fmt.Errorf("slot %s: feature %s has negative weight %d", sl.Name, f.Name, w)
+			}
+			mass += w
+		}
+		if picks > 0 && (len(f.Weights) != len(f.Prizes) || mass == 0) {
+			return fmt.Errorf("slot %s: feature %s has picks but %d weights (total %d) for %d prizes", sl.Name, f.Name, len(f.Weights), mass, len(f.Prizes))
+		}
+	}
+	sl.rnd.Solo(true) // slot is used by single goroutine
+	sl.rnd.Randomize(seeds...)
+	return nil
+}
+
+// Window height of reel.
+func (sl *Slot) height(reel int) int {
+	if len(sl.Height) == 0 {
+		return len(sl.Reels[reel])
+	}
+	return sl.Height[reel%len(sl.Height)]
+}
+
+// Window of reels at stops.
+func (sl *Slot) Window(stops []int) [][]symbol {
+	grid := make([][]symbol, len(sl.Reels))
+	for i, r := range sl.Reels {
+		h := sl.height(i)
+		for j := 0; j < h; j++ {
+			grid[i] = append(grid[i], r[(stops[i]+j)%len(r)])
+		}
+	}
+	return grid
+}
+
+// Name of symbol.
+func (sl *Slot) Symbol(s symbol) string {
+	if n, ok := sl.Names[s]; ok {
+		return n
+	}
+	return fmt.Sprint(s)
+}
+
+// Line win of symbol on count reels.
+func (sl *Slot) Line(s symbol, count int) float64 {
+	if p := sl.Pays[s]; count < len(p) {
+		return p[count]
+	}
+	return 0
+}
+
+// Payline win of symbol on count reels.
+func (sl *Slot) LinePay(s symbol, count int) float64 {
+	if p := sl.LinePays[s]; count < len(p) {
+		return p[count]
+	}
+	return 0
+}
+
+// Scatter win of count scatters.
+func (sl *Slot) ScatterPay(count int) float64 {
+	if count < len(sl.Scatters) {
+		return sl.Scatters[count]
+	}
+	return 0
+}
+
+// Pays orders: 1 from left, -1 from right.
+func (sl *Slot) orders() []int {
+	switch {
+	case sl.Order > 0:
+		return []int{1}
+	case sl.Order < 0:
+		return []int{-1}
+	}
+	return []int{1, -1}
+}
+
+// Plain wild and variants.
+func (sl *Slot) wilds() []Wild {
+	if sl.Wild == NoSymbol {
+		return sl.Wilds
+	}
+	return append([]Wild{{Symbol: sl.Wild}}, sl.Wilds...)
+}
+
+// Wild symbol multiplier, 0 if symbol is not wild.
+func (sl *Slot) multi(s symbol) int {
+	if s == sl.Wild && s != NoSymbol {
+		return 1
+	}
+	for _, w := range sl.Wilds {
+		if w.Symbol == s {
+			return w.multi()
+		}
+	}
+	return 0
+}
+
+// Window with expanding wilds over their reels.
+func (sl *Slot) expand(grid [][]symbol) [][]symbol {
+	var out [][]symbol
+	for _, w := range sl.Wilds {
+		if !w.Expand {
+			continue
+		}
+		for i, reel := range grid {
+			for _, s := range reel {
+				if s == w.Symbol {
+					if out == nil {
+						out = append([][]symbol{}, grid...)
+					}
+					out[i] = make([]symbol, len(reel))
+					for r := range reel {
+						out[i][r] = w.Symbol
+					}
+					break
+				}
+			}
+		}
+	}
+	if out == nil {
+		return grid
+	}
+	return out
+}
+
+// Hold sticky wilds over following spins (free spins), or release them.
+func (sl *Slot) Hold(on bool) {
+	sl.sticky = nil
+	if on {
+		sl.sticky = make([][]symbol, len(sl.Reels))
+		for i := range sl.sticky {
+			sl.sticky[i] = make([]symbol, sl.height(i))
+			for r := range sl.sticky[i] {
+				sl.sticky[i][r] = NoSymbol
+			}
+		}
+	}
+}
+
+// Put held sticky wilds on window and hold new ones.
+func (sl *Slot) stick(grid [][]symbol) [][]symbol {
+	if sl.sticky == nil {
+		return grid
+	}
+	out := make([][]symbol, len(grid))
+	for i, reel := range grid {
+		out[i] = append([]symbol{}, reel...)
+		for r, s := range out[i] {
+			if h := sl.sticky[i][r]; h != NoSymbol {
+				out[i][r] = h
+			} else {
+				for _, w := range sl.Wilds {
+					if w.Sticky && w.Symbol == s {
+						sl.sticky[i][r] = s
+					}
+				}
+			}
+		}
+	}
+	return out
+}
+
+// Reels in reverse order.
+func mirror(grid [][]symbol) [][]symbol {
+	m := make([][]symbol, len(grid))
+	for i, reel := range grid {
+		m[len(grid)-1-i] = reel
+	}
+	return m
+}
+
+// Reel of i-th reel in pays order.
+func reelOf(i, reels, order int) int {
+	if order < 0 {
+		return reels - 1 - i
+	}
+	return i
+}
+
+// Ways wins in pays orders with winning positions.
+func (sl *Slot) ways(grid [][]symbol) (wins []WaysItemNew) {
+	for _, order := range sl.orders() {
+		g := grid
+		if order < 0 {
+			g = mirror(grid)
+		}
+		for _, w := range WaysWilds(g, sl.wilds()...) {
+			if w.Symbol == sl.Scatter || (sl.Order == 0 && order < 0 && w.Width == len(grid)) {
+				continue
+			}
+			if w.Line = sl.Line(w.Symbol, w.Width); w.Line > 0 {
+				w.Order, w.Payout = order, w.Line*float64(w.Multi)
+				if order < 0 {
+					mask := make([]uint, len(g))
+					for i, m := range w.Mask {
+						mask[reelOf(i, len(g), order)] = m
+					}
+					w.Mask = mask
+				}
+				wins = append(wins, w)
+			}
+		}
+	}
+	return
+}
+
+// Win of symbols along line: leading wilds pay alone (as first wild) or
+// substitute first symbol, whichever pays more. Scatter is not substituted.
+// Multipliers of wilds on winning cells multiply line win.
+func (sl *Slot) lineWin(seq []symbol) (s symbol, count int, line float64, multi int) {
+	wilds, wm := 0, 1
+	for wilds < len(seq) {
+		m := sl.multi(seq[wilds])
+		if m == 0 {
+			break
+		}
+		wilds++
+		wm *= m
+	}
+	s, count, multi = NoSymbol, wilds, wm
+	if all := sl.wilds(); len(all) > 0 {
+		s = all[0].Symbol
+		line = sl.LinePay(s, wilds)
+	}
+	if wilds < len(seq) && seq[wilds] != sl.Scatter {
+		n, m := wilds+1, wm
+		for n < len(seq) {
+			if seq[n] == seq[wilds] {
+				n++
+			} else if x := sl.multi(seq[n]); x > 0 {
+				n++
+				m *= x
+			} else {
+				break
+			}
+		}
+		if p := sl.LinePay(seq[wilds], n); p*float64(m) >= line*float64(multi) && p > 0 {
+			s, count, line, multi = seq[wilds], n, p, m
+		}
+	}
+	return
+}
+
+// Paylines wins in pays orders with winning positions.
+func (sl *Slot) lines(grid [][]symbol) (wins []WaysItemNew) {
+	seq := make([]symbol, len(grid))
+	for l, rows := range sl.Lines {
+		for _, order := range sl.orders() {
+			for i := range seq {
+				reel := reelOf(i, len(grid), order)
+				seq[i] = grid[reel][rows[reel]]
+			}
+			s, count, line, multi := sl.lineWin(seq)
+			if line <= 0 || (sl.Order == 0 && order < 0 && count == len(grid)) {
+				continue
+			}
+			w := WaysItemNew{Symbol: s, Order: order, Line: line, Width: count, Ways: 1, Multi: multi, Payout: line * float64(multi), Payline: l + 1}
+			w.Mask = make([]uint, len(grid))
+			for i := 0; i < count; i++ {
+				reel := reelOf(i, len(grid), order)
+				w.Mask[reel] = 1 << rows[reel]
+			}
+			wins = append(wins, w)
+		}
+	}
+	return
+}
+
+// Ways, paylines and clusters wins.
+func (sl *Slot) wins(grid [][]symbol) []WaysItemNew {
+	wins := append(append(sl.ways(grid), sl.lines(grid)...), sl.clusters(grid)...)
+	sort.SliceStable(wins, func(a, b int) bool {
+		wa, wb := wins[a], wins[b]
+		if wa.Payline != wb.Payline {
+			return wa.Payline < wb.Payline
+		}
+		return wa.Symbol < wb.Symbol
+	})
+	return wins
+}
+
+// Scatters on window and their win.
+func (sl *Slot) scatters(grid [][]symbol) (count int, win float64) {
+	if sl.Scatter == NoSymbol {
+		return
+	}
+	for _, reel := range grid {
+		for _, s := range reel {
+			if s == sl.Scatter {
+				count++
+			}
+		}
+	}
+	return count, sl.ScatterPay(count)
+}
+
+// Evaluate window (after expanding wilds): ways, paylines and clusters
+// wins and scatter win.
+func (sl *Slot) Evaluate(grid [][]symbol) (spin Spin) {
+	grid = sl.expand(grid)
+	spin.Grid = grid
+	spin.Wins = sl.wins(grid)
+	for _, w := range spin.Wins {
+		spin.Win += w.Payout
+	}
+	spin.Scatter, spin.Bonus = sl.scatters(grid)
+	spin.Win += spin.Bonus
+	return
+}
+
+// Spin reels and evaluate window (with held sticky wilds and cascades).
+func (sl *Slot) Spin() Spin {
+	heights := make([]int, len(sl.Reels))
+	for i := range heights {
+		heights[i] = sl.height(i)
+	}
+	stops, grid := sl.rnd.Slot(sl.Reels, heights...)
+	spin := sl.Evaluate(sl.stick(grid))
+	spin.Stops = stops
+	if sl.Cascade {
+		sl.tumble(&spin)
+	}
+	return spin
+}
+
+// Spin n times holding sticky wilds (free spins).
+func (sl *Slot) Series(n int) (spins []Spin, win float64) {
+	sl.Hold(true)
+	defer sl.Hold(false)
+	for i := 0; i < n; i++ {
+		spin := sl.Spin()
+		spins, win = append(spins, spin), win+spin.Win
+	}
+	return
+}
+
+// Win category of symbol on count reels.
+func (sl *Slot) category(s symbol, count int) string {
+	return fmt.Sprintf("%s x%d", sl.Symbol(s), count)
+}
+
+// Win category of ways or payline win.
+func (sl *Slot) winCategory(w WaysItemNew) string {
+	switch {
+	case w.Payline > 0:
+		return sl.category(w.Symbol, w.Width) + " line"
+	case w.Payline < 0:
+		return sl.clusterCategory(w.Symbol, w.Width)
+	}
+	return sl.category(w.Symbol, w.Width)
+}
+
+// Win category of cluster, largest clusters together.
+func (sl *Slot) clusterCategory(s symbol, size int) string {
+	if top := len(sl.ClusterPays[s]) - 1; size >= top {
+		return fmt.Sprintf("%s x%d+ cluster", sl.Symbol(s), top)
+	}
+	return sl.category(s, size) + " cluster"
+}
+
+// Report order: ways and paylines pays by symbol and count, scatters and totals.
+func (sl *Slot) order() (order []string) {
+	for _, pt := range []struct {
+		pays   Paytable
+		suffix string
+	}{{sl.Pays, ""}, {sl.LinePays, " line"}, {sl.ClusterPays, " cluster"}} {
+		if len(pt.pays) == 0 {
+			continue
+		}
+		var symbols []symbol
+		for s := range pt.pays {
+			symbols = append(symbols, s)
+		}
+		sort.Ints(symbols)
+		order = append(order, "-")
+		for _, s := range symbols {
+			for n := len(pt.pays[s]) - 1; n > 0; n-- {
+				if pt.pays[s][n] <= 0 {
+					continue
+				}
+				if pt.suffix == " cluster" {
+					order = append(order, sl.clusterCategory(s, n))
+				} else {
+					order = append(order, sl.category(s, n)+pt.suffix)
+				}
+			}
+		}
+	}
+	order = append(order, "-")
+	for n := len(sl.Scatters) - 1; n > 0; n-- {
+		if sl.Scatters[n] > 0 {
+			order = append(order, sl.category(sl.Scatter, n))
+		}
+	}
+	order = append(order, "-", "ways", "lines", "clusters", "scatter", "cascades")
+	if len(sl.Features) > 0 {
+		order = append(order, "-", "base")
+		for _, f := range sl.Features {
+			order = append(order, f.Name)
+		}
+	}
+	return append(order, "total")
+}
+
+// Play spins with bet 1 and show report.
+func (sl *Slot) Simulate(spins int) {
+	st := &sl.Stats
+	st.Order = sl.order()
+	for i := 0; i < spins; i++ {
+		round := sl.Play()
+		spin := round.Spin
+		ways, lines, clusters := 0., 0., 0.
+		for _, w := range spin.Wins {
+			st.Add(sl.winCategory(w), w.Payout)
+			switch {
+			case w.Payline > 0:
+				lines += w.Payout
+			case w.Payline < 0:
+				clusters += w.Payout
+			default:
+				ways += w.Payout
+			}
+		}
+		for cat, win := range map[string]float64{"ways": ways, "lines": lines, "clusters": clusters} {
+			if win > 0 {
+				st.Add(cat, win)
+			}
+		}
+		if len(spin.Steps) > 2 { // winning cascades after spin
+			win := 0.
+			for _, step := range spin.Steps[1:] {
+				win += step.Win
+			}
+			st.Add("cascades", win)
+		}
+		if spin.Bonus > 0 {
+			st.Add(sl.category(sl.Scatter, spin.Scatter), spin.Bonus)
+			st.Add("scatter", spin.Bonus)
+		}
+		if len(sl.Features) > 0 && spin.Win > 0 {
+			st.Add("base", spin.Win)
+		}
+		if round.Feature != "" {
+			st.Add(round.Feature, round.Bonus)
+		}
+		st.Play(1, round.Win)
+	}
+	st.Report(sl.Name)
+}
+
+// Print window by rows.
+func (spin *Spin) Print(sl *Slot) {
+	for row, more := 0, true; more; row++ {
+		line := ""
+		more = false
+		for _, reel := range spin.Grid {
+			if row < len(reel) {
+				line += fmt.Sprintf("%-8s", sl.Symbol(reel[row]))
+				more = true
+			} else {
+				line += fmt.Sprintf("%-8s", "")
+			}
+		}
+		if more {
+			fmt.Println(line)
+		}
+	}
+	for _, w := range spin.Wins {
+		how := fmt.Sprintf("%v  %d ways", w.Factors, w.Ways)
+		switch {
+		case w.Payline > 0:
+			how = fmt.Sprintf("line %d", w.Payline)
+		case w.Payline < 0:
+			how = "cluster"
+		}
+		if w.Multi != w.Ways {
+			how += fmt.Sprintf(" (%d multiplied)", w.Multi)
+		}
+		fmt.Printf("%-8s x%d  %s  %v  %.2f\n", sl.Symbol(w.Symbol), w.Width, how, w.Mask, w.Payout)
+	}
+	if spin.Bonus > 0 {
+		fmt.Printf("%-8s x%d  %.2f\n", sl.Symbol(sl.Scatter), spin.Scatter, spin.Bonus)
+	}
+	fmt.Printf("win %.2f\n\n", spin.Win)
+}
+
+// Demo symbols.
+const (
+	symWild symbol = iota
+	symScatter
+	symGold
+	symRuby
+	symJade
+	symAce
+	symKing
+	symQueen
+	symJack
+	symTen
+)
+
+// 243 ways demo game, 5 reels of 3 symbols, wilds on reels 2 to 4.
+func GoldenWays() *Slot {
+	W, S, G, R, D, A, K, Q, J, T := symWild, symScatter, symGold, symRuby, symJade, symAce, symKing, symQueen, symJack, symTen
+	return &Slot{
+		Name: "Golden Ways",
+		Reels: [][]symbol{
+			{T, G, J, Q, S, K, T, A, R, J, Q, D, T, K, J, A, Q, T, G, J, K, D, Q, T, A, J, R, K, Q, T},
+			{Q, A, W, K, T, R, J, Q, D, K, T, A, S, J, G, Q, K, T, J, D, A, Q, W, K, T, R, J, A, Q, K},
+			{J, T, G, Q, K, W, A, J, R, T, Q, D, K, J, S, A, T, Q, G, K, J, W, A, D, T, Q, R, K, J, A},
+			{K, Q, R, T, A, W, J, K, D, Q, T, G, A, J, S, K, Q, T, R, A, J, W, K, D, Q, T, A, G, J, K},
+			{A, K, D, Q, J, T, G, A, R, K, Q, S, J, T, D, A, K, Q, R, J, T, G, A, K, D, Q, J, T, A, K},
+		},
+		Height:  []int{3},
+		Wild:    W,
+		Scatter: S,
+		Order:   1,
+		Pays: Paytable{
+			G: {0, 0, 0, 1.2, 3.75, 15},
+			R: {0, 0, 0, 0.9, 2.25, 9},
+			D: {0, 0, 0, 0.6, 1.5, 6},
+			A: {0, 0, 0, 0.3, 0.75, 3},
+			K: {0, 0, 0, 0.3, 0.75, 2.4},
+			Q: {0, 0, 0, 0.15, 0.45, 1.8},
+			J: {0, 0, 0, 0.15, 0.45, 1.5},
+			T: {0, 0, 0, 0.15, 0.3, 1.2},
+		},
+		Scatters: []float64{0, 0, 0, 5, 20, 100},
+		Names: map[symbol]string{
+			W: "WILD", S: "SCATTER", G: "GOLD", R: "RUBY", D: "JADE",
+			A: "A", K: "K", Q: "Q", J: "J", T: "10",
+		},
+	}
+}
+
+// Common paylines of 5 reels of 3 symbols.
+var FiveReelLines = [][]int{
+	{1, 1, 1, 1, 1}, {0, 0, 0, 0, 0}, {2, 2, 2, 2, 2}, {0, 1, 2, 1, 0}, {2, 1, 0, 1, 2},
+	{0, 0, 1, 2, 2}, {2, 2, 1, 0, 0}, {1, 0, 0, 0, 1}, {1, 2, 2, 2, 1}, {1, 0, 1, 2, 1},
+}
+
+// Demo game with reels of Golden Ways and 10 paylines paying both ways.
+func GoldenLines() *Slot {
+	sl := GoldenWays()
+	G, R, D, A, K, Q, J, T := symGold, symRuby, symJade, symAce, symKing, symQueen, symJack, symTen
+	sl.Name, sl.Order, sl.Pays, sl.Lines = "Golden Lines", 0, nil, FiveReelLines
+	sl.LinePays = Paytable{
+		G: {0, 0, 0, 3.4, 13.6, 68},
+		R: {0, 0, 0, 2.55, 8.5, 42.5},
+		D: {0, 0, 0, 1.7, 6.8, 25.5},
+		A: {0, 0, 0, 0.85, 3.4, 17},
+		K: {0, 0, 0, 0.85, 2.55, 13.6},
+		Q: {0, 0, 0, 0.5, 1.7, 8.5},
+		J: {0, 0, 0, 0.5, 1.7, 6.8},
+		T: {0, 0, 0, 0.35, 1.35, 5.1},
+	}
+	return sl
+}
+
+// Spin and simulate demo games.
+func ShowSlot(spins int) {
+	for _, sl := range []*Slot{GoldenWays(), GoldenLines()} {
+		if err := sl.Init(2024); err != nil {
+			fmt.Println(err)
+			return
+		}
+		spin := sl.Spin()
+		spin.Print(sl)
+		sl.Simulate(spins)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Sticky wilds stay on their positions for rest of series and are released after it.
+func TestSeriesSticky(t *testing.T) {
+	W, A, K, Q, J, T := symWild, symAce, symKing, symQueen, symJack, symTen
+	strip := []symbol{W, A, K, Q, J, A, K, Q, J, T}
+	sl := &Slot{
+		Name:    "Sticky",
+		Reels:   [][]symbol{strip, strip, strip},
+		Height:  []int{3},
+		Wild:    NoSymbol,
+		Wilds:   []Wild{{Symbol: W, Sticky: true}},
+		Scatter: NoSymbol,
+		Pays:    Paytable{A: {0, 0, 0, 1}},
+	}
+	if err := sl.Init(2024); err != nil {
+		t.Fatal(err)
+	}
+	spins, _ := sl.Series(30)
+	var held [3][3]bool
+	stuck := 0
+	for k, spin := range spins {
+		for i, reel := range spin.Grid {
+			for r, s := range reel {
+				if held[i][r] && s != W {
+					t.Fatalf("spin %d: sticky wild on reel %d row %d released", k+1, i+1, r+1)
+				}
+				if s == W && !held[i][r] {
+					held[i][r] = true
+					stuck++
+				}
+			}
+		}
+	}
+	if stuck == 0 {
+		t.Fatalf("no wild in %d spins", len(spins))
+	}
+	if sl.sticky != nil {
+		t.Fatal("sticky wilds held after series")
+	}
+	for k := 0; k < 30; k++ { // spins after series show plain window
+		if spin := sl.Spin(); !reflect.DeepEqual(spin.Grid, sl.Window(spin.Stops)) {
+			t.Fatalf("spin %d after series: window %v, reels show %v", k+1, spin.Grid, sl.Window(spin.Stops))
+		}
+	}
+}
@@ -0,0 +1,267 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"DHSimulator/rng"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// # Reel strip design
+//
+// Weights of symbols by reel are scaled to strip Length (by largest
+// remainders), or taken as counts if Length is 0. Scatters are spread
+// around strip with at least Gap other symbols between them (spare stops
+// are distributed over gaps by LCPRNG.Scatter), other symbols are shuffled
+// into remaining stops and High symbols next to each other (strip is
+// circular) are swapped apart.
+type StripDesign struct {
+	Weights []map[symbol]int `json:"weights"` // symbol weights by reel
+	Length  []int            `json:"length"`  // strip lengths by reel (cycled), 0 for weights as counts
+	High    []symbol         `json:"high"`    // symbols never adjacent on strip
+	Scatter symbol           `json:"scatter"` // scatter symbol
+	Gap     int              `json:"gap"`     // min other symbols between scatters
+	rnd     rng.LCPRNG       // strips shuffler
+}
+
+// Max swaps per stop when separating high symbols.
+var MaxStripSwaps = 1000
+
+// Initialize design randomizer.
+func (sd *StripDesign) Init(seeds ...uint64) error {
+	if len(sd.Weights) == 0 {
+		return fmt.Errorf("strips: no reels")
+	}
+	if sd.Gap < 0 {
+		return fmt.Errorf("strips: bad scatters gap %d", sd.Gap)
+	}
+	for i, w := range sd.Weights {
+		sum := 0
+		for _, x := range w {
+			if x < 0 {
+				return fmt.Errorf("strips: reel %d has negative weight", i+1)
+			}
+			sum += x
+		}
+		if sum == 0 {
+			return fmt.Errorf("strips: reel %d has no weights", i+1)
+		}
+	}
+	sd.rnd.Solo(true)
+	sd.rnd.Randomize(seeds...)
+	return nil
+}
+
+// Symbol counts of reels.
+func SymbolCounts(reels [][]symbol) []map[symbol]int {
+	counts := make([]map[symbol]int, len(reels))
+	for i, reel := range reels {
+		counts[i] = map[symbol]int{}
+		for _, s := range reel {
+			counts[i][s]++
+		}
+	}
+	return counts
+}
+
+// Symbols of reel (sorted) and their counts on strip.
+func (sd *StripDesign) counts(i int) (symbols []symbol, counts []int) {
+	sum := 0
+	for s, w := range sd.Weights[i] {
+		if w > 0 {
+			symbols = append(symbols, s)
+			sum += w
+		}
+	}
+	sort.Ints(symbols)
+	counts = make([]int, len(symbols))
+	length := 0
+	if len(sd.Length) > 0 {
+		length = sd.Length[i%len(sd.Length)]
+	}
+	if length <= 0 {
+		for j, s := range symbols {
+			counts[j] = sd.Weights[i][s]
+		}
+		return
+	}
+	/*
+		count = ⌊w · length / Σw⌋, largest remainders get one more
+	*/
+	rest := make([]int, len(symbols))
+	left := length
+	for j, s := range symbols {
+		x := sd.Weights[i][s] * length
+		counts[j], rest[j] = x/sum, x%sum
+		left -= counts[j]
+	}
+	by := make([]int, len(symbols))
+	for j := range by {
+		by[j] = j
+	}
+	sort.SliceStable(by, func(a, b int) bool { return rest[by[a]] > rest[by[b]] })
+	for _, j := range by[:left] {
+		counts[j]++
+	}
+	return
+}
+
+// Adjacent high symbols pairs on circular strip.
+func (sd *StripDesign) conflicts(strip []symbol, high map[symbol]bool) (n int) {
+	if len(strip) < 2 {
+		return
+	}
+	for i, s := range strip {
+		if high[s] && high[strip[(i+1)%len(strip)]] {
+			n++
+		}
+	}
+	return
+}
+
+// Generate strip of reel i.
+func (sd *StripDesign) strip(i int, high map[symbol]bool) ([]symbol, error) {
+	symbols, counts := sd.counts(i)
+	var others []symbol
+	scatters := 0
+	for j, s := range symbols {
+		if s == sd.Scatter {
+			scatters = counts[j]
+			continue
+		}
+		for k := 0; k < counts[j]; k++ {
+			others = append(others, s)
+		}
+	}
+	length := scatters + len(others)
+	if length == 0 {
+		return nil, fmt.Errorf("strips: reel %d is empty", i+1)
+	}
+	strip := make([]symbol, length)
+	taken := make([]bool, length)
+	if scatters > 0 {
+		spare := length - scatters*(1+sd.Gap)
+		if spare < 0 {
+			return nil, fmt.Errorf("strips: reel %d, %d scatters with gap %d do not fit %d stops", i+1, scatters, sd.Gap, length)
+		}
+		gaps := sd.rnd.Scatter(spare, scatters)
+		pos := sd.rnd.Choice(length)
+		for _, g := range gaps {
+			strip[pos], taken[pos] = sd.Scatter, true
+			pos = (pos + 1 + sd.Gap + g) % length
+		}
+	}
+	sd.rnd.Shuffle(&others)
+	for pos, k := 0, 0; pos < length; pos++ {
+		if !taken[pos] {
+			strip[pos] = others[k]
+			k++
+		}
+	}
+
+	// swap high symbol of conflict with random other stop while it helps
+	n := sd.conflicts(strip, high)
+	for tries := MaxStripSwaps * length; n > 0 && tries > 0; tries-- {
+		a := sd.rnd.Choice(length)
+		if !high[strip[a]] || !high[strip[(a+1)%length]] {
+			continue
+		}
+		b := sd.rnd.Choice(length)
+		if taken[b] || high[strip[b]] {
+			continue
+		}
+		strip[a], strip[b] = strip[b], strip[a]
+		if m := sd.conflicts(strip, high); m < n {
+			n = m
+		} else {
+			strip[a], strip[b] = strip[b], strip[a]
+		}
+	}
+	if n > 0 {
+		return nil, fmt.Errorf("strips: reel %d, %d adjacent high symbols left", i+1, n)
+	}
+	return strip, nil
+}
+
+// Generate reel strips.
+func (sd *StripDesign) Strips() ([][]symbol, error) {
+	high := map[symbol]bool{}
+	for _, s := range sd.High {
+		if s != sd.Scatter {
+			high[s] = true
+		}
+	}
+	reels := make([][]symbol, len(sd.Weights))
+	for i := range reels {
+		strip, err := sd.strip(i, high)
+		if err != nil {
+			return nil, err
+		}
+		reels[i] = strip
+	}
+	return reels, nil
+}
+
+// Generate tries strips for slot and keep ones of exact rtp closest to target.
+//
+// Slot keeps its own reels on error (or no tries).
+func (sd *StripDesign) Fit(sl *Slot, rtp float64, tries int) (best SlotMath, err error) {
+	if len(sd.Weights) != len(sl.Reels) {
+		return best, fmt.Errorf("strips: %d reels designed for %d reels of %s", len(sd.Weights), len(sl.Reels), sl.Name)
+	}
+	orig := sl.Reels
+	var reels [][]symbol
+	for t := 0; t < tries; t++ {
+		if sl.Reels, err = sd.Strips(); err != nil {
+			break
+		}
+		var sm SlotMath
+		if sm, err = sl.Exact(); err != nil {
+			break
+		}
+		if reels == nil || math.Abs(sm.RTP-rtp) < math.Abs(best.RTP-rtp) {
+			best, reels = sm, sl.Reels
+		}
+	}
+	if err != nil || reels == nil {
+		best, reels = SlotMath{}, orig
+	}
+	sl.Reels = reels
+	return
+}
+
+// Print reel strips by symbol names.
+func (sl *Slot) PrintStrips() {
+	for i, reel := range sl.Reels {
+		names := make([]string, len(reel))
+		for j, s := range reel {
+			names[j] = sl.Symbol(s)
+		}
+		fmt.Printf("reel %d (%d):  %s\n", i+1, len(reel), strings.Join(names, " "))
+	}
+}
+
+// Design Golden Ways strips twice as long (two scatters per reel) for 95% rtp.
+func DesignStrips(tries int) error {
+	sl := GoldenWays()
+	sd := StripDesign{
+		Weights: SymbolCounts(sl.Reels),
+		Length:  []int{60},
+		High:    []symbol{symWild, symGold, symRuby, symJade},
+		Scatter: symScatter,
+		Gap:     12,
+	}
+	if err := sd.Init(2024); err != nil {
+		return err
+	}
+	sm, err := sd.Fit(sl, 0.95, tries)
+	if err != nil {
+		return err
+	}
+	sl.PrintStrips()
+	sm.Print(sl)
+	return nil
+}
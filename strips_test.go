@@ -0,0 +1,124 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Largest remainder counts sum to strip length and match weights on strips.
+func TestStripCounts(t *testing.T) {
+	A, K, Q := symAce, symKing, symQueen
+	for _, c := range []struct {
+		name    string
+		weights map[symbol]int
+		length  int
+		counts  []int // of A, K, Q
+	}{
+		{"exact", map[symbol]int{A: 5, K: 3, Q: 2}, 20, []int{10, 6, 4}},
+		{"tie", map[symbol]int{A: 1, K: 1, Q: 1}, 10, []int{4, 3, 3}},
+		{"largest remainder", map[symbol]int{A: 7, K: 2, Q: 1}, 6, []int{4, 1, 1}},
+		{"weights as counts", map[symbol]int{A: 2, K: 1, Q: 4}, 0, []int{2, 1, 4}},
+	} {
+		sd := StripDesign{Weights: []map[symbol]int{c.weights}, Length: []int{c.length}, Scatter: NoSymbol}
+		if err := sd.Init(2024); err != nil {
+			t.Fatal(err)
+		}
+		_, counts := sd.counts(0)
+		sum := 0
+		for _, n := range counts {
+			sum += n
+		}
+		if !reflect.DeepEqual(counts, c.counts) || (c.length > 0 && sum != c.length) {
+			t.Errorf("%s: counts %v (sum %d), want %v", c.name, counts, sum, c.counts)
+		}
+		reels, err := sd.Strips()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := SymbolCounts(reels)[0]
+		for j, s := range []symbol{A, K, Q} {
+			if got[s] != c.counts[j] {
+				t.Errorf("%s: strip has %d of symbol %d, want %d", c.name, got[s], s, c.counts[j])
+			}
+		}
+	}
+}
+
+// Scatters keep gap (around strip end too) and high symbols are never adjacent.
+func TestStripSpacing(t *testing.T) {
+	S, W, G, R, A, K, Q, J := symScatter, symWild, symGold, symRuby, symAce, symKing, symQueen, symJack
+	high := map[symbol]bool{W: true, G: true, R: true}
+	for _, c := range []struct {
+		name    string
+		weights map[symbol]int
+		gap     int
+	}{
+		{"tight", map[symbol]int{S: 3, A: 3, K: 3, Q: 3}, 3}, // no spare stops
+		{"spare", map[symbol]int{S: 2, W: 2, G: 3, R: 3, A: 5, K: 5, Q: 5, J: 5}, 6},
+		{"high", map[symbol]int{W: 2, G: 2, R: 2, A: 6, K: 6}, 0},
+	} {
+		sd := StripDesign{Weights: []map[symbol]int{c.weights, c.weights}, High: []symbol{W, G, R}, Scatter: S, Gap: c.gap}
+		if err := sd.Init(2024); err != nil {
+			t.Fatal(err)
+		}
+		for try := 0; try < 100; try++ {
+			reels, err := sd.Strips()
+			if err != nil {
+				t.Fatalf("%s: %v", c.name, err)
+			}
+			for i, strip := range reels {
+				n := len(strip)
+				last := -1
+				for p, s := range strip {
+					if s == S {
+						last = p
+					}
+				}
+				for p, s := range strip {
+					if s == S {
+						if d := (p - last + n) % n; last >= 0 && d <= c.gap && (d > 0 || p != last) {
+							t.Fatalf("%s: reel %d scatters at %d and %d closer than gap %d: %v", c.name, i+1, last, p, c.gap, strip)
+						}
+						last = p
+					}
+					if high[s] && high[strip[(p+1)%n]] {
+						t.Fatalf("%s: reel %d adjacent high symbols at %d: %v", c.name, i+1, p, strip)
+					}
+				}
+			}
+		}
+	}
+}
+
+// Fit leaves slot reels unchanged on error.
+func TestFitError(t *testing.T) {
+	for _, c := range []struct {
+		name  string
+		slot  func() *Slot
+		gap   int
+		reels int
+	}{
+		{"reels count", GoldenWays, 0, 3},
+		{"scatters do not fit", GoldenWays, 100, 0},
+		{"no exact math", GemClusters, 0, 0},
+	} {
+		sl := c.slot()
+		orig := sl.Reels
+		weights := SymbolCounts(sl.Reels)
+		if c.reels > 0 {
+			weights = weights[:c.reels]
+		}
+		sd := StripDesign{Weights: weights, Scatter: symScatter, Gap: c.gap}
+		if err := sd.Init(2024); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := sd.Fit(sl, 0.95, 3); err == nil {
+			t.Errorf("%s: no error", c.name)
+		}
+		if !reflect.DeepEqual(sl.Reels, orig) || &sl.Reels[0][0] != &orig[0][0] {
+			t.Errorf("%s: reels changed", c.name)
+		}
+	}
+}
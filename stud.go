@@ -0,0 +1,153 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"fmt"
+)
+
+// Stud player cards.
+type StudHand struct {
+	Down Cards // face down (hole) cards
+	Up   Cards // face up (door) cards
+}
+
+// All cards of player.
+func (sh *StudHand) Pile() bits {
+	return sh.Down.Pile() | sh.Up.Pile()
+}
+
+// # Seven card stud and Razz
+//
+// Two down and one up card on 3rd street, one up card on 4th to 6th
+// and one down card on 7th street. If deck runs short on 7th street,
+// single common card is dealt face up for all players.
+type Stud struct {
+	Razz    bool       // ace-to-five low instead of high
+	Hands   []StudHand // players cards
+	Common  Cards      // common card (short deck on 7th street)
+	Street  int        // last dealt street (3 to 7)
+	BringIn int        // player forced to bring in
+	deck    *Deck      // dealing deck
+	bp      BitPoker   // hands evaluator
+}
+
+// New stud hand for players, dealt from deck up to 3rd street.
+func (st *Stud) Init(players int, razz bool, deck *Deck) {
+	st.bp.Classic()
+	st.Razz, st.deck = razz, deck
+	st.Hands, st.Common, st.Street = make([]StudHand, players), nil, 2
+	deck.Reset()
+	st.Deal()
+}
+
+// Deal next street, false if all streets are dealt.
+func (st *Stud) Deal() bool {
+	if st.Street >= 7 {
+		return false
+	}
+	st.Street++
+	switch st.Street {
+	case 3:
+		for i := range st.Hands {
+			st.Hands[i].Down = st.deck.Deal(2)
+		}
+		for i := range st.Hands {
+			st.Hands[i].Up = st.deck.Deal(1)
+		}
+		st.BringIn = st.bringIn()
+	case 7:
+		if st.deck.Rest < len(st.Hands) {
+			st.Common = st.deck.Deal(1)
+			break
+		}
+		for i := range st.Hands {
+			st.Hands[i].Down = append(st.Hands[i].Down, st.deck.Draw())
+		}
+	default:
+		for i := range st.Hands {
+			st.Hands[i].Up = append(st.Hands[i].Up, st.deck.Draw())
+		}
+	}
+	return true
+}
+
+// Deal all remaining streets.
+func (st *Stud) DealAll() {
+	for st.Deal() {
+	}
+}
+
+// Player with lowest door card (highest in Razz) brings in.
+//
+// Ties are broken by suit in bridge order (♣ ♦ ♥ ♠),
+// lowest suit brings in for stud and highest for Razz.
+func (st *Stud) bringIn() (p int) {
+	bridge := [5]int{0, 3, 1, 2, 0} // by card suit ♠ ♦ ♥ ♣
+	value := func(c Card) int {
+		k := c.Kind
+		if st.Razz {
+			if k == 14 {
+				k = 1 // ace is low
+			}
+			return 4*k + bridge[c.Suit]
+		}
+		return -(4*k + bridge[c.Suit])
+	}
+	for i, h := range st.Hands {
+		if value(h.Up[0]) > value(st.Hands[p].Up[0]) {
+			p = i
+		}
+	}
+	return
+}
+
+// Razz low: best ace-to-five low from any 5 cards of hold and desk.
+func (bp *BitPoker) RazzLow(hold, desk bits) (string, int, bits, bits) {
+	desk ^= hold & desk
+	code, rank, _, best := bp.LowHoldem(0, hold|desk, 0, LowAceFive)
+	return code, rank, hold & best, desk & best
+}
+
+// Players cards and common card.
+func (st *Stud) piles() ([]bits, bits) {
+	players := make([]bits, len(st.Hands))
+	for i := range st.Hands {
+		players[i] = st.Hands[i].Pile()
+	}
+	return players, st.Common.Pile()
+}
+
+// Showdown standings: best 5 of 7 cards, high for stud and low for Razz.
+func (st *Stud) Showdown() ([]string, [][]int) {
+	players, common := st.piles()
+	if st.Razz {
+		return st.bp.PlayLowHand(players, common, st.bp.RazzLow)
+	}
+	return st.bp.PlayPokerHand(players, common, st.bp.TexasHoldem)
+}
+
+// Stud Hi-Lo showdown with 8 or better low.
+func (st *Stud) ShowdownHiLo() HiLo {
+	players, common := st.piles()
+	return st.bp.PlayHiLoHand(players, common, st.bp.TexasHoldem, st.bp.StudLow)
+}
+
+// Deal and show single stud or Razz hand.
+func ShowStud(players int, razz bool) {
+	var st Stud
+	st.Init(players, razz, &Dealer)
+	fmt.Printf("player %d brings in\n", st.BringIn+1)
+	st.DealAll()
+	for i, h := range st.Hands {
+		fmt.Printf("player %d  down %-12s  up %s\n", i+1, h.Down.Faces(), h.Up.Faces())
+	}
+	if len(st.Common) > 0 {
+		fmt.Println("common", st.Common.Faces())
+	}
+	codes, stand := st.Showdown()
+	for i, c := range codes {
+		fmt.Printf("%d. %v  %s\n", i+1, stand[i], c)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,64 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import "testing"
+
+// Lowest door card brings in (highest in Razz), ties by suit ♣ ♦ ♥ ♠.
+func TestStudBringIn(t *testing.T) {
+	for _, c := range []struct {
+		razz bool
+		up   []string
+		want int
+	}{
+		{false, []string{"K♠", "3♥", "3♣", "7♦"}, 2},
+		{false, []string{"3♣", "2♠", "A♣"}, 1},
+		{false, []string{"5♠", "5♥", "5♦"}, 2},
+		{true, []string{"K♥", "Q♦", "K♠"}, 2},
+		{true, []string{"2♣", "A♠", "9♣"}, 2},
+		{true, []string{"A♠", "2♣"}, 1}, // ace is low
+	} {
+		var st Stud
+		st.Razz = c.razz
+		for _, f := range c.up {
+			st.Hands = append(st.Hands, StudHand{Up: Make(f)})
+		}
+		if p := st.bringIn(); p != c.want {
+			t.Errorf("razz %v, door cards %v: player %d brings in, want %d", c.razz, c.up, p, c.want)
+		}
+	}
+}
+
+// Streets of full table, common card when deck runs short.
+func TestStudDeal(t *testing.T) {
+	for _, players := range []int{2, 7, 8} {
+		var deck Deck
+		deck.Croupier.Solo(true)
+		deck.Seed(2024)
+		var st Stud
+		st.Init(players, false, &deck)
+		st.DealAll()
+		common := players*7 > 52
+		if (len(st.Common) == 1) != common {
+			t.Errorf("%d players: %d common cards", players, len(st.Common))
+		}
+		var all bits
+		for i, h := range st.Hands {
+			down := 3
+			if common {
+				down = 2
+			}
+			if len(h.Down) != down || len(h.Up) != 4 {
+				t.Errorf("%d players: player %d has %d down and %d up cards", players, i+1, len(h.Down), len(h.Up))
+			}
+			if p := h.Pile(); all&p != 0 {
+				t.Errorf("%d players: player %d card dealt twice", players, i+1)
+			} else {
+				all |= p
+			}
+		}
+		if _, stand := st.Showdown(); len(stand) == 0 {
+			t.Errorf("%d players: no showdown", players)
+		}
+	}
+}
@@ -0,0 +1,403 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Betting policy: wanted bet of seat in current round.
+//
+// Less than to call folds (checks if nothing to call),
+// equal calls and more raises (at least minimal raise, at most all in).
+type Policy func(tbl *Table, seat int) int
+
+// Player at table.
+type Seat struct {
+	Name   string `json:"name"`   // player name
+	Policy Policy `json:"-"`      // betting policy
+	Buyin  int    `json:"buyin"`  // stack after rebuy
+	Stack  int    `json:"stack"`  // chips
+	Hands  int    `json:"hands"`  // hands played
+	Wins   int    `json:"wins"`   // hands won (any pot)
+	Net    int    `json:"net"`    // chips won minus chips bet
+	Rebuys int    `json:"rebuys"` // rebuys
+
+	hold   bits // hole cards
+	bet    int  // bet in current round
+	total  int  // bet in current hand
+	folded bool // out of hand
+}
+
+// # Texas Holdem table
+//
+// Blinds, dealing from seeded deck, four betting rounds, main and side pots.
+// Rake and jackpot drop are taken only if flop is dealt (no flop, no drop)
+// and never from uncalled bet.
+type Table struct {
+	Seats   []*Seat `json:"seats"`    // players
+	Small   int     `json:"small"`    // small blind
+	Big     int     `json:"big"`      // big blind
+	Rake    float64 `json:"rake"`     // rake rate of pot
+	RakeCap int     `json:"rake_cap"` // max rake per hand
+	Drop    int     `json:"drop"`     // jackpot drop per hand
+	Button  int     `json:"button"`   // dealer seat
+	Hands   int     `json:"hands"`    // hands played
+	Flops   int     `json:"flops"`    // flops dealt
+	Raked   int     `json:"raked"`    // total rake
+	Dropped int     `json:"dropped"`  // total jackpot drop
+
+	Board bits // community cards
+	level int  // bet to call in current round
+	raise int  // minimal raise
+	deck  Deck
+	bp    BitPoker
+}
+
+// New table with blinds, deck seeded from seeds (system state if none).
+func (tbl *Table) Init(small, big int, seeds ...uint64) {
+	tbl.Small, tbl.Big = small, big
+	tbl.deck.Croupier.Solo(true) // table is used by single goroutine
+	tbl.deck.Seed(seeds...)
+	tbl.bp.Classic()
+}
+
+// Add player with policy and buy-in.
+func (tbl *Table) Sit(name string, policy Policy, buyin int) {
+	tbl.Seats = append(tbl.Seats, &Seat{Name: name, Policy: policy, Buyin: buyin, Stack: buyin})
+}
+
+// Next seat after seat.
+func (tbl *Table) next(seat int) int {
+	return (seat + 1) % len(tbl.Seats)
+}
+
+// Chips to call for seat.
+func (tbl *Table) ToCall(seat int) int {
+	return tbl.level - tbl.Seats[seat].bet
+}
+
+// Chips in pot (all bets of hand).
+func (tbl *Table) Pot() (pot int) {
+	for _, s := range tbl.Seats {
+		pot += s.total
+	}
+	return
+}
+
+// Hole cards of seat (for its policy).
+func (tbl *Table) Hold(seat int) bits {
+	return tbl.Seats[seat].hold
+}
+
+// Put chips of seat into pot (all in at most).
+func (tbl *Table) put(s *Seat, chips int) {
+	if chips > s.Stack {
+		chips = s.Stack
+	}
+	s.Stack -= chips
+	s.bet += chips
+	s.total += chips
+}
+
+// Seats still in hand, and those able to bet.
+func (tbl *Table) active() (live, betting int) {
+	for _, s := range tbl.Seats {
+		if !s.folded {
+			live++
+			if s.Stack > 0 {
+				betting++
+			}
+		}
+	}
+	return
+}
+
+// Betting round from first seat, false if single player is left.
+func (tbl *Table) round(first int) bool {
+	pending := make([]bool, len(tbl.Seats)) // seats to act
+	for i, s := range tbl.Seats {
+		pending[i] = !s.folded && s.Stack > 0
+	}
+	for i, left := first, len(tbl.Seats); left > 0; i = tbl.next(i) {
+		s := tbl.Seats[i]
+		if !pending[i] {
+			left--
+			continue
+		}
+		if live, betting := tbl.active(); live < 2 || (betting < 2 && tbl.ToCall(i) <= 0) {
+			break
+		}
+		pending[i], left = false, len(tbl.Seats)
+
+		want, call := s.Policy(tbl, i), tbl.ToCall(i)
+		switch {
+		case want < tbl.level && call > 0:
+			s.folded = true
+		case want <= tbl.level || s.Stack <= call:
+			tbl.put(s, call)
+		default:
+			if min := tbl.level + tbl.raise; want < min {
+				want = min
+			}
+			tbl.put(s, want-s.bet)
+			if s.bet > tbl.level {
+				if s.bet-tbl.level >= tbl.raise {
+					tbl.raise = s.bet - tbl.level
+				}
+				tbl.level = s.bet
+				for j, o := range tbl.Seats { // others act again
+					pending[j] = j != i && !o.folded && o.Stack > 0
+				}
+			}
+		}
+	}
+	for _, s := range tbl.Seats {
+		s.bet = 0
+	}
+	tbl.level, tbl.raise = 0, tbl.Big
+	live, _ := tbl.active()
+	return live > 1
+}
+
+// Play single hand.
+func (tbl *Table) Play() {
+	n := len(tbl.Seats)
+	tbl.deck.Reset()
+	tbl.Board = 0
+	for _, s := range tbl.Seats {
+		if s.Stack < tbl.Big { // rebuy
+			s.Stack = s.Buyin
+			s.Rebuys++
+		}
+		s.hold, s.bet, s.total, s.folded = 0, 0, 0, false
+		s.Hands++
+	}
+	for i := range tbl.Seats { // deal from left of button
+		hand := tbl.deck.Deal(2)
+		tbl.Seats[(tbl.Button+1+i)%n].hold = hand.Pile()
+	}
+
+	// blinds (button posts small blind heads up)
+	sb := tbl.next(tbl.Button)
+	if n == 2 {
+		sb = tbl.Button
+	}
+	bb := tbl.next(sb)
+	tbl.put(tbl.Seats[sb], tbl.Small)
+	tbl.put(tbl.Seats[bb], tbl.Big)
+	tbl.level, tbl.raise = tbl.Big, tbl.Big
+
+	more := tbl.round(tbl.next(bb))
+	for _, cards := range []int{3, 1, 1} {
+		if !more {
+			break
+		}
+		deal := tbl.deck.Deal(cards)
+		tbl.Board |= deal.Pile()
+		if cards == 3 {
+			tbl.Flops++
+		}
+		if _, betting := tbl.active(); betting > 1 {
+			more = tbl.round(tbl.next(tbl.Button))
+		}
+	}
+
+	tbl.settle()
+	tbl.Button = tbl.next(tbl.Button)
+	tbl.Hands++
+}
+
+// Rake, jackpot drop and pots distribution.
+func (tbl *Table) settle() {
+	n := len(tbl.Seats)
+
+	// standings of players in hand
+	var in []int // seats in hand
+	var holds []bits
+	for i, s := range tbl.Seats {
+		if !s.folded {
+			in, holds = append(in, i), append(holds, s.hold)
+		}
+	}
+	var stand [][]int
+	if len(in) > 1 {
+		_, stand = tbl.bp.PlayTexasHand(holds, tbl.Board)
+	} else {
+		stand = [][]int{{0}}
+	}
+
+	// main and side pots by levels of bets of players in hand
+	levels := []int{}
+	for _, p := range in {
+		levels = append(levels, tbl.Seats[p].total)
+	}
+	sort.Ints(levels)
+	type pot struct {
+		chips int
+		seats []int // eligible seats
+		from  int   // seats with chips in pot
+	}
+	var pots []pot
+	prev := 0
+	for _, l := range levels {
+		if l == prev {
+			continue
+		}
+		var p pot
+		for i, s := range tbl.Seats {
+			if c := s.total; c > prev {
+				if c > l {
+					c = l
+				}
+				p.chips += c - prev
+				p.from++
+				if !s.folded && s.total >= l {
+					p.seats = append(p.seats, i)
+				}
+			}
+		}
+		pots, prev = append(pots, p), l
+	}
+	for _, s := range tbl.Seats { // chips of folded players above last level
+		if s.folded && s.total > prev {
+			pots[len(pots)-1].chips += s.total - prev
+			pots[len(pots)-1].from++
+		}
+	}
+
+	// rake and drop from main pot up, uncalled bet is not contested
+	if tbl.Board != 0 {
+		contested := 0
+		for _, p := range pots {
+			if p.from > 1 {
+				contested += p.chips
+			}
+		}
+		rake := int(float64(contested) * tbl.Rake)
+		if tbl.RakeCap > 0 && rake > tbl.RakeCap {
+			rake = tbl.RakeCap
+		}
+		for _, take := range []struct {
+			chips int
+			total *int
+		}{{rake, &tbl.Raked}, {tbl.Drop, &tbl.Dropped}} {
+			for i := range pots {
+				if pots[i].from < 2 {
+					continue
+				}
+				c := take.chips
+				if c > pots[i].chips {
+					c = pots[i].chips
+				}
+				pots[i].chips -= c
+				take.chips -= c
+				*take.total += c
+			}
+		}
+	}
+
+	// winners of each pot, odd chips from left of button
+	won := make([]int, n)
+	for _, p := range pots {
+		var winners []int
+		for _, group := range stand {
+			for _, g := range group {
+				for _, e := range p.seats {
+					if in[g] == e {
+						winners = append(winners, e)
+					}
+				}
+			}
+			if len(winners) > 0 {
+				break
+			}
+		}
+		if len(winners) == 0 {
+			continue
+		}
+		sort.Slice(winners, func(a, b int) bool {
+			return (winners[a]-tbl.Button+n-1)%n < (winners[b]-tbl.Button+n-1)%n
+		})
+		share, odd := p.chips/len(winners), p.chips%len(winners)
+		for k, w := range winners {
+			won[w] += share
+			if k < odd {
+				won[w]++
+			}
+		}
+	}
+
+	for i, s := range tbl.Seats {
+		s.Stack += won[i]
+		s.Net += won[i] - s.total
+		if won[i] > 0 {
+			s.Wins++
+		}
+	}
+}
+
+// Play hands and report.
+func (tbl *Table) Simulate(hands int) {
+	for h := 0; h < hands; h++ {
+		tbl.Play()
+	}
+	tbl.Report()
+}
+
+// Seats win rates, rake and jackpot drop.
+func (tbl *Table) Report() {
+	fmt.Printf("%d hands,  blinds %d / %d,  %d flops (%.2f%%)\n", tbl.Hands, tbl.Small, tbl.Big, tbl.Flops, 100*float64(tbl.Flops)/float64(tbl.Hands))
+	for _, s := range tbl.Seats {
+		fmt.Printf("%-10s  wins %6.2f%%  net %10d  %8.2f bb / 100  rebuys %d\n", s.Name,
+			100*float64(s.Wins)/float64(s.Hands), s.Net, 100*float64(s.Net)/float64(tbl.Big)/float64(s.Hands), s.Rebuys)
+	}
+	fmt.Printf("rake %d (%.4f / hand),  jackpot drop %d (%.4f / hand)\n\n", tbl.Raked, float64(tbl.Raked)/float64(tbl.Hands),
+		tbl.Dropped, float64(tbl.Dropped)/float64(tbl.Hands))
+}
+
+// Always check or call.
+func Passive(tbl *Table, seat int) int {
+	return tbl.level
+}
+
+// Raise pot every time.
+func Maniac(tbl *Table, seat int) int {
+	return tbl.level + tbl.Pot() + tbl.ToCall(seat)
+}
+
+// Play good starting hands, bet strong hands, call with pair or better.
+func Tight(tbl *Table, seat int) int {
+	hold := tbl.Hold(seat)
+	if tbl.Board == 0 {
+		a, b := tbl.bp.Leftmost(hold)>>2, tbl.bp.Rightmost(hold)>>2
+		switch {
+		case a == b && a >= 8: // TT+
+			return tbl.level + 3*tbl.Big
+		case a == b || (a >= 8 && b >= 8): // pairs, two broadways
+			return tbl.level
+		}
+		return 0
+	}
+	switch code := tbl.bp.Category(tbl.bp.Rank(hold | tbl.Board)); {
+	case code >= 2: // 2 pair or better
+		return tbl.level + tbl.Pot()/2
+	case code == 1:
+		return tbl.level
+	}
+	return 0
+}
+
+// Few policies at same table.
+func ShowTable(hands int) {
+	var tbl Table
+	tbl.Init(1, 2, 2024)
+	tbl.Rake, tbl.RakeCap, tbl.Drop = 0.05, 3, 1
+	tbl.Sit("tight", Tight, 200)
+	tbl.Sit("passive", Passive, 200)
+	tbl.Sit("maniac", Maniac, 200)
+	tbl.Sit("tight 2", Tight, 200)
+	tbl.Sit("passive 2", Passive, 200)
+	tbl.Simulate(hands)
+}
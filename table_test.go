@@ -0,0 +1,63 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import "testing"
+
+// Table with seats holding cards and bets of finished hand.
+func settleTable(t *testing.T, board string, seats []Seat) *Table {
+	t.Helper()
+	var tbl Table
+	tbl.bp.Classic()
+	tbl.Board = parsePile(t, &tbl.bp, board)
+	for i := range seats {
+		tbl.Seats = append(tbl.Seats, &seats[i])
+	}
+	return &tbl
+}
+
+// Three all in at different levels, covering bet partly uncalled.
+func TestTableSidePots(t *testing.T) {
+	var bp BitPoker
+	bp.Classic()
+	hold := func(cards string) bits { return parsePile(t, &bp, cards) }
+	tbl := settleTable(t, "2c7d9hJs3s", []Seat{
+		{Name: "short", hold: hold("AhAd"), total: 50},   // best
+		{Name: "middle", hold: hold("KhKd"), total: 100}, // second
+		{Name: "long", hold: hold("QhQd"), total: 200},   // third
+		{Name: "cover", hold: hold("4c5c"), total: 300, Stack: 700},
+	})
+	tbl.Rake = 0.1
+	tbl.settle()
+	// main 4 · 50, side 3 · 50 and 2 · 100, uncalled 100 returned
+	rake := (200 + 150 + 200) / 10
+	for i, want := range []int{200 - rake, 150, 200, 700 + 100} {
+		if s := tbl.Seats[i]; s.Stack != want {
+			t.Errorf("%s: stack %d, want %d", s.Name, s.Stack, want)
+		}
+	}
+	if tbl.Raked != rake {
+		t.Errorf("rake %d, want %d (uncalled bet raked)", tbl.Raked, rake)
+	}
+}
+
+// Odd chip of split pot goes to first winner left of button.
+func TestTableOddChip(t *testing.T) {
+	var bp BitPoker
+	bp.Classic()
+	hold := func(cards string) bits { return parsePile(t, &bp, cards) }
+	tbl := settleTable(t, "AhKhQhJhTh", []Seat{ // board plays
+		{Name: "button", hold: hold("2c3c"), total: 10},
+		{Name: "folded", hold: hold("4c4d"), total: 5, folded: true},
+		{Name: "big", hold: hold("2d3d"), total: 10},
+	})
+	tbl.settle()
+	for i, want := range []int{12, 0, 13} {
+		if s := tbl.Seats[i]; s.Stack != want {
+			t.Errorf("%s: stack %d, want %d", s.Name, s.Stack, want)
+		}
+	}
+	if tbl.Seats[0].Wins != 1 || tbl.Seats[2].Wins != 1 || tbl.Seats[1].Net != -5 {
+		t.Errorf("wins %d and %d, folded net %d", tbl.Seats[0].Wins, tbl.Seats[2].Wins, tbl.Seats[1].Net)
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"DHSimulator/rng"
+	"fmt"
+)
+
+// Simulation statistics by category (as DiamondHunt report).
+type Tally struct {
+	Plays int                     `json:"plays"` // games played
+	Bet   rng.StatCalc            `json:"bet"`   // bets
+	Win   rng.StatCalc            `json:"win"`   // wins of games
+	Cats  map[string]rng.StatCalc `json:"cats"`  // wins by category
+	Order []string                `json:"order"` // categories in report, "-" for ruler, "" for empty line
+}
+
+// Add win of category (0 counts hit only).
+func (t *Tally) Add(cat string, x float64) {
+	if t.Cats == nil {
+		t.Cats = map[string]rng.StatCalc{}
+	}
+	c := t.Cats[cat]
+	c.Cat = cat
+	c.Add(x)
+	t.Cats[cat] = c
+}
+
+// Add played game with bet and total win.
+func (t *Tally) Play(bet, win float64) {
+	t.Plays++
+	t.Bet.Add(bet)
+	t.Win.Add(win)
+	if win > 0 {
+		t.Add("total", win)
+	}
+}
+
+// Return to player.
+func (t *Tally) RTP() float64 {
+	if t.Bet.Sum == 0 {
+		return 0
+	}
+	return t.Win.Sum / t.Bet.Sum
+}
+
+// Print categories count, sum, probability, rtp and rate.
+func (t *Tally) Report(title string) {
+	fmt.Printf("\n%s,  %d games,  bet %.2f,  win %.2f,  rtp %.5f%%\n\n", title, t.Plays, t.Bet.Sum, t.Win.Sum, 100*t.RTP())
+	fmt.Println("category                         count              sum     probability         rtp             rate")
+	for _, d := range t.Order {
+		if d == "-" {
+			fmt.Print("----------------------------------------------------------------------------------------------------")
+		}
+		if s, e := t.Cats[d]; e {
+			prob := float64(s.Cnt) / float64(t.Plays)
+			rtp := s.Sum / t.Bet.Sum
+			fmt.Printf("%-26s  %10d  ", d, s.Cnt)
+			if s.Sum > 0 {
+				fmt.Printf("%15.2f", s.Sum)
+			} else {
+				fmt.Printf("%15s", "")
+			}
+			fmt.Printf("  %13.9f%%  ", 100*prob)
+			if rtp > 0 {
+				fmt.Printf("%9.5f%%", 100*rtp)
+			} else {
+				fmt.Printf("%10s", "")
+			}
+			fmt.Printf("  %15.2f", 1/prob)
+		} else if d != "" && d != "-" {
+			continue
+		}
+		fmt.Println()
+	}
+	fmt.Println()
+}
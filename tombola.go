@@ -0,0 +1,180 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"DHSimulator/rng"
+	"fmt"
+)
+
+// Tombola balls, ticket rows, columns and numbers per row.
+const (
+	TombolaBalls   = 90
+	TombolaRows    = 3
+	TombolaColumns = 9
+	TombolaRow     = 5
+)
+
+// Tombola wins by numbers hit in a row and whole ticket.
+var TombolaNames = []string{"", "", "ambo", "terno", "quaterna", "cinquina", "tombola"}
+
+// # Tombola
+//
+// Ticket has 3 rows of 5 numbers in 9 columns by tens (1-9, 10-19, ...,
+// 80-90), each column used. Draw balls are drawn and ticket pays only its
+// best win: ambo, terno, quaterna or cinquina by most numbers hit in a row,
+// or tombola for all 15. Rows are disjoint, so hits by rows are
+// multivariate hypergeometric:
+/*
+	prob(h₁, h₂, h₃) = C(5, h₁) · C(5, h₂) · C(5, h₃) · C(75, draw - Σh) / C(90, draw)
+*/
+type Tombola struct {
+	Draw int        `json:"draw"` // balls drawn
+	Pays []float64  `json:"pays"` // pays by best row hits (index), tombola last
+	rnd  rng.LCPRNG // mixer
+}
+
+// Tombola with classic pays.
+func ClassicTombola() *Tombola {
+	return &Tombola{
+		Draw: 30,
+		Pays: []float64{0, 0, 0, 1, 2.5, 25, 10000},
+	}
+}
+
+// Initialize mixer.
+func (t *Tombola) Init(seeds ...uint64) error {
+	if t.Draw < 1 || t.Draw > TombolaBalls {
+		return fmt.Errorf("tombola: bad draw %d", t.Draw)
+	}
+	if len(t.Pays) > len(TombolaNames) {
+		return fmt.Errorf("tombola: %d pays", len(t.Pays))
+	}
+	t.rnd.Solo(true) // game is used by single goroutine
+	t.rnd.Randomize(seeds...)
+	return nil
+}
+
+// Game name.
+func (t *Tombola) Title() string {
+	return fmt.Sprintf("Tombola %d balls", t.Draw)
+}
+
+// Random ticket by rows, 0 in blank cells.
+func (t *Tombola) Ticket() (ticket [TombolaRows][TombolaColumns]int) {
+	var used [TombolaColumns]int
+	for done := false; !done; {
+		used = [TombolaColumns]int{}
+		for r := range ticket {
+			for _, c := range t.rnd.Combination(TombolaColumns, TombolaRow) {
+				used[c] |= 1 << r
+			}
+		}
+		done = true
+		for _, u := range used {
+			done = done && u != 0
+		}
+	}
+	for c, u := range used {
+		lo, hi := 10*c, 10*c+9
+		if c == 0 {
+			lo = 1
+		}
+		if c == TombolaColumns-1 {
+			hi = TombolaBalls
+		}
+		rows := 0
+		for r := range ticket {
+			rows += u >> r & 1
+		}
+		numbers := t.rnd.Combination(hi-lo+1, rows)
+		for r := range ticket {
+			if u>>r&1 != 0 {
+				ticket[r][c] = lo + numbers[0]
+				numbers = numbers[1:]
+			}
+		}
+	}
+	return
+}
+
+// Drawn balls in order.
+func (t *Tombola) Draws() []int {
+	return t.rnd.Tombola()[:t.Draw]
+}
+
+// Best win of ticket (index of TombolaNames).
+func (t *Tombola) Best(ticket [TombolaRows][TombolaColumns]int, draws []int) (best int) {
+	var drawn [TombolaBalls + 1]bool
+	for _, n := range draws {
+		drawn[n] = true
+	}
+	all := 0
+	for _, row := range ticket {
+		hits := 0
+		for _, n := range row {
+			if n > 0 && drawn[n] {
+				hits++
+			}
+		}
+		if hits > best {
+			best = hits
+		}
+		all += hits
+	}
+	if all == TombolaRows*TombolaRow {
+		best++
+	}
+	return
+}
+
+// Pay of best win.
+func (t *Tombola) Pay(best int) float64 {
+	if best < len(t.Pays) {
+		return t.Pays[best]
+	}
+	return 0
+}
+
+// Exact math by best win.
+func (t *Tombola) Exact() (hits []DrawHit) {
+	prob := make([]float64, len(TombolaNames))
+	items := []int{TombolaRow, TombolaRow, TombolaRow, TombolaBalls - TombolaRows*TombolaRow}
+	for h1 := 0; h1 <= TombolaRow; h1++ {
+		for h2 := 0; h2 <= TombolaRow; h2++ {
+			for h3 := 0; h3 <= TombolaRow; h3++ {
+				rest := t.Draw - h1 - h2 - h3
+				if rest < 0 {
+					continue
+				}
+				best := h1
+				if h2 > best {
+					best = h2
+				}
+				if h3 > best {
+					best = h3
+				}
+				if h1+h2+h3 == TombolaRows*TombolaRow {
+					best++
+				}
+				prob[best] += rng.MultiHypGeomDist(items, []int{h1, h2, h3, rest})
+			}
+		}
+	}
+	for best := len(prob) - 1; best > 0; best-- {
+		if pay := t.Pay(best); pay > 0 {
+			hits = append(hits, DrawHit{Cat: TombolaNames[best], Prob: prob[best], Pay: pay, RTP: prob[best] * pay})
+		}
+	}
+	return
+}
+
+// Play round with random ticket.
+func (t *Tombola) Round(st *Tally) {
+	best := t.Best(t.Ticket(), t.Draws())
+	win := t.Pay(best)
+	if win > 0 {
+		st.Add(TombolaNames[best], win)
+	}
+	st.Play(1, win)
+}
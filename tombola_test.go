@@ -0,0 +1,98 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import "testing"
+
+// Tombola refuses bad draw and more pays than wins.
+func TestTombolaInit(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		draw int
+		pays int
+		ok   bool
+	}{
+		{"classic", 30, 7, true},
+		{"no draw", 0, 7, false},
+		{"draw over balls", TombolaBalls + 1, 7, false},
+		{"too many pays", 30, 8, false},
+	} {
+		tb := ClassicTombola()
+		tb.Draw = c.draw
+		tb.Pays = make([]float64, c.pays)
+		if err := tb.Init(2024); (err == nil) != c.ok {
+			t.Errorf("%s: Init error %v", c.name, err)
+		}
+	}
+}
+
+// Tickets have 5 numbers in each row, every column used by its tens
+// (1-9, 10-19, ..., 80-90) and distinct numbers.
+func TestTombolaTicket(t *testing.T) {
+	tb := ClassicTombola()
+	if err := tb.Init(2024); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 1000; i++ {
+		ticket := tb.Ticket()
+		var seen [TombolaBalls + 1]bool
+		var used [TombolaColumns]bool
+		for r, row := range ticket {
+			count := 0
+			for c, n := range row {
+				if n == 0 {
+					continue
+				}
+				lo, hi := 10*c, 10*c+9
+				if c == 0 {
+					lo = 1
+				}
+				if c == TombolaColumns-1 {
+					hi = TombolaBalls
+				}
+				if n < lo || n > hi || seen[n] {
+					t.Fatalf("ticket %v: bad or repeated number %d in column %d", ticket, n, c)
+				}
+				seen[n], used[c] = true, true
+				count++
+			}
+			if count != TombolaRow {
+				t.Fatalf("ticket %v: %d numbers in row %d", ticket, count, r)
+			}
+		}
+		for c, u := range used {
+			if !u {
+				t.Fatalf("ticket %v: column %d not used", ticket, c)
+			}
+		}
+	}
+}
+
+// Best win and its pay of fixed draws.
+func TestTombolaBest(t *testing.T) {
+	tb := ClassicTombola()
+	ticket := [TombolaRows][TombolaColumns]int{
+		{1, 10, 20, 30, 40, 0, 0, 0, 0},
+		{0, 0, 21, 31, 41, 51, 61, 0, 0},
+		{0, 0, 0, 0, 42, 52, 62, 72, 90},
+	}
+	for _, c := range []struct {
+		draws []int
+		best  int
+	}{
+		{[]int{2, 11, 89}, 0},
+		{[]int{1, 21, 42}, 1},
+		{[]int{1, 10}, 2},
+		{[]int{1, 10, 21, 31, 20}, 3},
+		{[]int{42, 52, 62, 72, 1, 21}, 4},
+		{[]int{1, 10, 20, 30, 40, 41, 51}, 5},
+		{[]int{1, 10, 20, 30, 40, 21, 31, 41, 51, 61, 42, 52, 62, 72, 90}, 6},
+	} {
+		if best := tb.Best(ticket, c.draws); best != c.best || tb.Pay(best) != tb.Pays[c.best] {
+			t.Errorf("draws %v: best %d pay %g, want %d (%s)", c.draws, best, tb.Pay(best), c.best, TombolaNames[c.best])
+		}
+	}
+	if pay := tb.Pay(len(tb.Pays)); pay != 0 {
+		t.Errorf("win beyond paytable pays %g", pay)
+	}
+}
@@ -0,0 +1,418 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"fmt"
+	bitops "math/bits"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Joker card (53rd card, outside of standard pack).
+const joker bits = 1 << 52
+
+// Video poker payout classes.
+const (
+	vpNothing       = iota // no win
+	vpLowPair              // pair below paying pair
+	vpHighPair             // jacks or better, kings or better, ...
+	vpTwoPair              // two pair
+	vpTrips                // 3 of kind
+	vpStraight             // straight
+	vpFlush                // flush
+	vpFullHouse            // full house
+	vpQuads                // 4 of kind (5s to Ks with bonus)
+	vpQuadsLow             // 4 of kind 2s to 4s (bonus)
+	vpQuadsAces            // 4 of kind aces (bonus)
+	vpStraightFlush        // straight flush
+	vpFiveKind             // 5 of kind
+	vpWildRoyal            // royal flush with wild cards
+	vpFourDeuces           // 4 deuces
+	vpRoyal                // natural royal flush
+	vpClasses              // number of classes
+)
+
+// Payout classes names.
+var VideoPokerNames = [vpClasses]string{"nothing", "low pair", "high pair", "2 pair", "3 of kind",
+	"straight", "flush", "full house", "4 of kind", "4 of kind 2-4", "4 of kind aces",
+	"straight flush", "5 of kind", "wild royal flush", "4 deuces", "royal flush"}
+
+// # Video poker game
+//
+// Five cards draw against paytable, configurable by pack, wild cards,
+// lowest paying pair and paytable. Variant settings (Pack, Wild, Pair, Bonus)
+// must not change after first use, paytable may.
+type VideoPoker struct {
+	Name  string         // variant name
+	Pack  bits           // pack of cards (joker bit 52 for 53 cards)
+	Wild  bits           // wild cards
+	Pair  int            // lowest paying pair kind (0 = 2, 12 = A)
+	Bonus bool           // 4 of kind paid by kind?
+	Pays  [vpClasses]int // paytable for 1 coin
+
+	bp    BitPoker              // cards views
+	cards []int                 // pack as bit indices
+	class []uint8               // class of 5 cards hands by colex index
+	count [5][][vpClasses]int32 // 5 cards hands containing subset, by subset size and colex index
+	worth [5][]float64          // total pay of hands containing subset
+	paid  [vpClasses]int        // paytable of worth
+	binom [53 + 1][5 + 1]int    // binomial coefficients
+}
+
+// Jacks or Better, full pay 9/6 (99.543904%).
+func JacksOrBetter() *VideoPoker {
+	vp := &VideoPoker{Name: "Jacks or Better 9/6", Pack: standard_pack, Pair: 9}
+	vp.Pays[vpHighPair], vp.Pays[vpTwoPair], vp.Pays[vpTrips] = 1, 2, 3
+	vp.Pays[vpStraight], vp.Pays[vpFlush], vp.Pays[vpFullHouse] = 4, 6, 9
+	vp.Pays[vpQuads], vp.Pays[vpStraightFlush], vp.Pays[vpRoyal] = 25, 50, 800
+	return vp
+}
+
+// Bonus Poker 8/5 (99.165973%).
+func BonusPoker() *VideoPoker {
+	vp := JacksOrBetter()
+	vp.Name, vp.Bonus = "Bonus Poker 8/5", true
+	vp.Pays[vpFlush], vp.Pays[vpFullHouse] = 5, 8
+	vp.Pays[vpQuads], vp.Pays[vpQuadsLow], vp.Pays[vpQuadsAces] = 25, 40, 80
+	return vp
+}
+
+// Deuces Wild, full pay (100.761961%).
+func DeucesWild() *VideoPoker {
+	vp := &VideoPoker{Name: "Deuces Wild full pay", Pack: standard_pack, Wild: 0xF, Pair: 13}
+	vp.Pays[vpTrips], vp.Pays[vpStraight], vp.Pays[vpFlush] = 1, 2, 2
+	vp.Pays[vpFullHouse], vp.Pays[vpQuads], vp.Pays[vpStraightFlush] = 3, 5, 9
+	vp.Pays[vpFiveKind], vp.Pays[vpWildRoyal] = 15, 25
+	vp.Pays[vpFourDeuces], vp.Pays[vpRoyal] = 200, 800
+	return vp
+}
+
+// Joker Poker, kings or better, full pay (100.646297%).
+func JokerPoker() *VideoPoker {
+	vp := &VideoPoker{Name: "Joker Poker kings or better", Pack: standard_pack | joker, Wild: joker, Pair: 11}
+	vp.Pays[vpHighPair], vp.Pays[vpTwoPair], vp.Pays[vpTrips] = 1, 1, 2
+	vp.Pays[vpStraight], vp.Pays[vpFlush], vp.Pays[vpFullHouse] = 3, 5, 7
+	vp.Pays[vpQuads], vp.Pays[vpStraightFlush], vp.Pays[vpWildRoyal] = 20, 50, 100
+	vp.Pays[vpFiveKind], vp.Pays[vpRoyal] = 200, 800
+	return vp
+}
+
+// Payout class of 5 cards hand.
+func (vp *VideoPoker) Class(hand bits) int {
+	w := bitops.OnesCount64(hand & vp.Wild)
+	natural := hand &^ vp.Wild
+
+	var count [13]int
+	km, sm := 0, 0 // kinds and suits masks of naturals
+	maxc, kind, pairs := 0, -1, 0
+	for p := natural; p != 0; p &= p - 1 {
+		c := bitops.TrailingZeros64(p)
+		k := c >> 2
+		count[k]++
+		km |= 1 << k
+		sm |= 1 << (c & 3)
+	}
+	for k, c := range count {
+		if c >= maxc && c > 0 {
+			maxc, kind = c, k // highest of most frequent kinds
+		}
+		if c == 2 {
+			pairs++
+		}
+	}
+
+	distinct := maxc <= 1
+	flush := bitops.OnesCount(uint(sm)) <= 1
+	straight := false                      // all naturals fit into 5 kinds window
+	high := distinct && km&^(0x1F<<8) == 0 // all naturals in T to A
+	if distinct {
+		low := km<<1 | km>>12 // ace both low and high
+		for s := 0; s <= 9 && !straight; s++ {
+			win := low & (0x1F << s)
+			straight = win>>1|(win&1)<<12 == km
+		}
+	}
+
+	switch {
+	case w == 0 && flush && km == 0x1F<<8:
+		return vpRoyal
+	case w == 4:
+		return vpFourDeuces
+	case w > 0 && flush && straight && high:
+		return vpWildRoyal
+	case maxc+w >= 5:
+		return vpFiveKind
+	case flush && straight:
+		return vpStraightFlush
+	case maxc+w >= 4:
+		if vp.Bonus {
+			if kind == 12 {
+				return vpQuadsAces
+			} else if kind <= 2 {
+				return vpQuadsLow
+			}
+		}
+		return vpQuads
+	case maxc+w >= 3 && bitops.OnesCount(uint(km)) == 2:
+		return vpFullHouse
+	case flush:
+		return vpFlush
+	case straight:
+		return vpStraight
+	case maxc+w >= 3:
+		return vpTrips
+	case pairs == 2:
+		return vpTwoPair
+	case maxc+w >= 2:
+		if maxc == 1 { // wild pairs highest natural
+			kind = bitops.Len(uint(km)) - 1
+		}
+		if kind >= vp.Pair {
+			return vpHighPair
+		}
+		return vpLowPair
+	}
+	return vpNothing
+}
+
+// Colex index of sorted cards subset.
+func (vp *VideoPoker) index(cards []int) (i int) {
+	for j, c := range cards {
+		i += vp.binom[c][j+1]
+	}
+	return
+}
+
+// Build hands tables (about 3 million hands).
+func (vp *VideoPoker) ensure() {
+	if vp.class != nil {
+		return
+	}
+	vp.bp.Classic()
+	for n := range vp.binom {
+		vp.binom[n][0] = 1
+		for k := 1; k <= 5 && k <= n; k++ {
+			vp.binom[n][k] = vp.binom[n-1][k-1] + vp.binom[n-1][k]
+		}
+	}
+	vp.cards = vp.bp.Inflate(vp.Pack)
+	n := len(vp.cards)
+	vp.class = make([]uint8, vp.binom[n][5])
+	for k := range vp.count {
+		vp.count[k] = make([][vpClasses]int32, vp.binom[n][k])
+	}
+
+	// hands by positions in pack, so colex index of positions
+	var s sampler
+	var pos [5]int
+	for s.Init(1<<n-1, 5); !s.Eof(); {
+		h := s.Next()
+		var hand bits
+		for j, p := 0, h; p != 0; j, p = j+1, p&(p-1) {
+			pos[j] = bitops.TrailingZeros64(p)
+			hand |= 1 << vp.cards[pos[j]]
+		}
+		c := vp.Class(hand)
+		vp.class[vp.index(pos[:])] = uint8(c)
+		for m := 0; m < 31; m++ { // proper subsets
+			i, k := 0, 0
+			for j := 0; j < 5; j++ {
+				if m>>j&1 != 0 {
+					k++
+					i += vp.binom[pos[j]][k]
+				}
+			}
+			vp.count[k][i][c]++
+		}
+	}
+}
+
+// Total pay of hands containing subsets for current paytable.
+func (vp *VideoPoker) prepare() {
+	vp.ensure()
+	if vp.worth[0] != nil && vp.paid == vp.Pays {
+		return
+	}
+	vp.paid = vp.Pays
+	for k, counts := range vp.count {
+		vp.worth[k] = make([]float64, len(counts))
+		for i, cnt := range counts {
+			for c, n := range cnt {
+				vp.worth[k][i] += float64(n) * float64(vp.Pays[c])
+			}
+		}
+	}
+}
+
+// Dealt hand as sorted positions in pack, false if not 5 cards of pack.
+func (vp *VideoPoker) positions(deal bits) (pos [5]int, ok bool) {
+	if deal&vp.Pack != deal || bitops.OnesCount64(deal) != 5 {
+		return
+	}
+	j := 0
+	for p, c := range vp.cards {
+		if deal>>c&1 != 0 {
+			pos[j] = p
+			j++
+		}
+	}
+	return pos, true
+}
+
+// Subsets of dealt hand: sizes and colex indices.
+func (vp *VideoPoker) subsets(pos [5]int) (size, index [32]int) {
+	for m := 0; m < 32; m++ {
+		for j := 0; j < 5; j++ {
+			if m>>j&1 != 0 {
+				size[m]++
+				index[m] += vp.binom[pos[j]][size[m]]
+			}
+		}
+	}
+	return
+}
+
+// Expected values of all 32 holds by hold mask of dealt positions.
+//
+// Draws avoiding discarded cards by inclusion-exclusion:
+/*
+	pay(hold) = Σ        (-1)^|s - hold| · worth(s)
+	           hold ⊆ s ⊆ deal
+*/
+// followed by division with number of draws.
+func (vp *VideoPoker) values(size, index [32]int) (ev [32]float64) {
+	n := len(vp.cards) - 5
+	for m := range ev {
+		if size[m] == 5 {
+			ev[m] = float64(vp.Pays[vp.class[index[m]]])
+		} else {
+			ev[m] = vp.worth[size[m]][index[m]]
+		}
+	}
+	for b := 1; b < 32; b <<= 1 { // Möbius transform over supersets
+		for m := range ev {
+			if m&b == 0 {
+				ev[m] -= ev[m|b]
+			}
+		}
+	}
+	for m := range ev {
+		ev[m] /= float64(vp.binom[n][5-size[m]])
+	}
+	return
+}
+
+// Hold with expected value.
+type HoldEV struct {
+	Hold bits    `json:"hold"` // held cards
+	EV   float64 `json:"ev"`   // expected pay for 1 coin
+}
+
+// All 32 holds of dealt hand by expected value, best first.
+func (vp *VideoPoker) Holds(deal bits) (holds []HoldEV) {
+	vp.prepare()
+	pos, ok := vp.positions(deal)
+	if !ok {
+		return
+	}
+	ev := vp.values(vp.subsets(pos))
+	for m, v := range ev {
+		var hold bits
+		for j := 0; j < 5; j++ {
+			if m>>j&1 != 0 {
+				hold |= 1 << vp.cards[pos[j]]
+			}
+		}
+		holds = append(holds, HoldEV{hold, v})
+	}
+	sort.SliceStable(holds, func(i, j int) bool { return holds[i].EV > holds[j].EV })
+	return
+}
+
+// Optimal hold of dealt hand and its expected value.
+func (vp *VideoPoker) Hold(deal bits) (bits, float64) {
+	if holds := vp.Holds(deal); len(holds) > 0 {
+		return holds[0].Hold, holds[0].EV
+	}
+	return 0, 0
+}
+
+// # Video poker exact return
+//
+// All deals played with optimal hold, returns RTP
+// and final hands distribution by payout class.
+func (vp *VideoPoker) RTP() (rtp float64, dist [vpClasses]float64) {
+	vp.prepare()
+	n := len(vp.cards)
+	deals := float64(vp.binom[n][5])
+	var s sampler
+	var pos [5]int
+	for s.Init(1<<n-1, 5); !s.Eof(); {
+		h := s.Next()
+		for j, p := 0, h; p != 0; j, p = j+1, p&(p-1) {
+			pos[j] = bitops.TrailingZeros64(p)
+		}
+		size, index := vp.subsets(pos)
+		ev := vp.values(size, index)
+		best := 0
+		for m, v := range ev {
+			if v > ev[best] {
+				best = m
+			}
+		}
+		rtp += ev[best]
+
+		// final hands of best hold by class
+		draws := float64(vp.binom[n-5][5-size[best]])
+		for m := best; m < 32; m = (m + 1) | best { // supersets
+			sign := 1 - 2*float64((size[m]-size[best])&1)
+			if size[m] == 5 {
+				dist[vp.class[index[m]]] += sign / draws
+			} else {
+				for c, k := range vp.count[size[m]][index[m]] {
+					dist[c] += sign * float64(k) / draws
+				}
+			}
+		}
+	}
+	rtp /= deals
+	for c := range dist {
+		dist[c] /= deals
+	}
+	return
+}
+
+// Cards view, joker as "Jk".
+func (vp *VideoPoker) View(pile bits) string {
+	vp.ensure()
+	view := vp.bp.Humanize(pile & standard_pack)
+	if pile&joker != 0 {
+		view = append(view, "Jk")
+	}
+	return strings.Join(view, " ")
+}
+
+// Paytable, exact return and optimal hold of few hands.
+func ShowVideoPoker() {
+	for _, vp := range []*VideoPoker{JacksOrBetter(), BonusPoker(), DeucesWild(), JokerPoker()} {
+		start := time.Now()
+		rtp, dist := vp.RTP()
+		fmt.Printf("%s  rtp = %.4f%%  (%.1f\")\n", vp.Name, 100*rtp, time.Since(start).Seconds())
+		for c := vpClasses - 1; c > vpNothing; c-- {
+			if vp.Pays[c] > 0 {
+				fmt.Printf("  %-18s %4d  %12.9f%%  %8.4f%%\n", VideoPokerNames[c], vp.Pays[c], 100*dist[c], 100*dist[c]*float64(vp.Pays[c]))
+			}
+		}
+		var deal bits
+		for _, cards := range []string{"AsKsQsJs9d", "2s2dKh7c4s", "Jh Td 9c 8s 2h"} {
+			if deal, _ = vp.bp.Parse(cards); vp.Wild == joker {
+				deal = deal&^(deal&-deal) | joker // joker instead of lowest card
+			}
+			hold, ev := vp.Hold(deal)
+			fmt.Printf("  deal %-18s hold %-18s ev = %.6f\n", vp.View(deal), vp.View(hold), ev)
+		}
+		fmt.Println()
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"math"
+	"testing"
+)
+
+// Exact return of full pay variants (as in their doc comments).
+func TestVideoPokerRTP(t *testing.T) {
+	if testing.Short() {
+		t.Skip("about 5 seconds per variant")
+	}
+	for _, c := range []struct {
+		vp  *VideoPoker
+		rtp float64 // percent
+	}{
+		{JacksOrBetter(), 99.543904},
+		{BonusPoker(), 99.165973},
+		{DeucesWild(), 100.761961},
+		{JokerPoker(), 100.646297},
+	} {
+		c := c
+		t.Run(c.vp.Name, func(t *testing.T) {
+			t.Parallel()
+			rtp, dist := c.vp.RTP()
+			if math.Abs(100*rtp-c.rtp) > 1e-6 {
+				t.Errorf("rtp %.9f%%, want %.6f%%", 100*rtp, c.rtp)
+			}
+			sum, pay := 0., 0.
+			for k, p := range dist {
+				sum += p
+				pay += p * float64(c.vp.Pays[k])
+			}
+			if math.Abs(sum-1) > 1e-9 || math.Abs(pay-rtp) > 1e-9 {
+				t.Errorf("final hands sum %.12f, pay %.12f of rtp %.12f", sum, pay, rtp)
+			}
+		})
+	}
+}
+
+// Known optimal holds.
+func TestVideoPokerHold(t *testing.T) {
+	job, bonus, deuces, jokers := JacksOrBetter(), BonusPoker(), DeucesWild(), JokerPoker()
+	for _, c := range []struct {
+		vp         *VideoPoker
+		deal, hold string
+	}{
+		{job, "AsKsQsJs9s", "AsKsQsJs"}, // 4 to royal over made flush
+		{job, "TsTd9c8d7h", "TsTd"},     // low pair over 4 to outside straight
+		{job, "AsKsQsJsTs", "AsKsQsJsTs"},
+		{bonus, "AsKsQsJs9s", "AsKsQsJs"},
+		{bonus, "TsTd9c8d7h", "TsTd"},
+		{deuces, "2s2dKh7c4s", "2s2d"}, // deuces only
+		{deuces, "AsKsQsJs9s", "AsKsQsJs"},
+		{jokers, "AsKsQsJs9s", "AsKsQsJs"},
+	} {
+		deal, hold := parsePile(t, &c.vp.bp, c.deal), parsePile(t, &c.vp.bp, c.hold)
+		holds := c.vp.Holds(deal)
+		if len(holds) != 32 {
+			t.Fatalf("%s %s: %d holds", c.vp.Name, c.deal, len(holds))
+		}
+		for i := 1; i < len(holds); i++ {
+			if holds[i].EV > holds[i-1].EV {
+				t.Fatalf("%s %s: holds not sorted by ev", c.vp.Name, c.deal)
+			}
+		}
+		if h, ev := c.vp.Hold(deal); h != hold || ev != holds[0].EV {
+			t.Errorf("%s %s: hold %s (ev %.6f), want %s", c.vp.Name, c.deal, c.vp.View(h), ev, c.vp.View(hold))
+		}
+	}
+}
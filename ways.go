@@ -1,6 +1,8 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+)
 
 type symbol = int
 
@@ -12,62 +14,178 @@ type WaysItemNew struct {
 	Width   int     `json:"m,omitempty"` // line width
 	Wilds   int     `json:"e,omitempty"` // excluded wilds only lines
 	Ways    int     `json:"w,omitempty"` // number of ways = Π factors - wilds
-	Payout  float64 `json:"p,omitempty"` // payout = line * ways
+	Multi   int     `json:"u,omitempty"` // multiplied ways = Σ Π wild multipliers of ways
+	Payout  float64 `json:"p,omitempty"` // payout = line * multiplied ways
+	Payline int     `json:"n,omitempty"` // payline number, 0 for ways, -1 for clusters
+	Mask    []uint  `json:"x,omitempty"` // winning positions by reel (bit per row)
+}
+
+// Wild symbol variant.
+type Wild struct {
+	Symbol symbol `json:"s"`           // wild symbol
+	Multi  int    `json:"m,omitempty"` // multiplier of ways through wild (1 if 0)
+	Expand bool   `json:"x,omitempty"` // expands over whole reel
+	Sticky bool   `json:"k,omitempty"` // stays on window over free spins
+}
+
+// Multiplier of wild.
+func (w Wild) multi() int {
+	if w.Multi > 0 {
+		return w.Multi
+	}
+	return 1
 }
 
 func Ways(grid [][]symbol, wild symbol) (result []WaysItemNew) {
-	width := len(grid)
+	return WaysWilds(grid, Wild{Symbol: wild})
+}
+
+// Symbol cells of reel.
+type waysCell struct {
+	count  int  // cells
+	weight int  // cells multiplied (wilds)
+	mask   uint // cells positions
+}
+
+// Wild multiplier of symbol, 0 if not wild.
+func wildMulti(wilds []Wild, s symbol) int {
+	for _, w := range wilds {
+		if w.Symbol == s {
+			return w.multi()
+		}
+	}
+	return 0
+}
 
-	symbols := map[symbol]int{} // list of symbols
+// # Ways with wild variants
+//
+// Any wild substitutes symbol and multiplies way by its multiplier. Ways of
+// wilds only are taken off symbols ways (with their multiplied ways) and
+// paid once as first wild. Mask holds winning cells of each reel.
+//
+// Grid is scanned once into cells table by reel and symbol, reels may be of
+// any height (Megaways), up to 64 rows. Symbols come in order of first
+// appearance, wilds first.
+func WaysWilds(grid [][]symbol, wilds ...Wild) (result []WaysItemNew) {
+	width := len(grid)
+	lo, hi := 0, -1 // symbols range
 	for _, reel := range grid {
-		for _, symbol := range reel {
-			if symbol != wild {
-				symbols[symbol]++
+		for _, s := range reel {
+			if hi < lo {
+				lo, hi = s, s
+			} else if s < lo {
+				lo = s
+			} else if s > hi {
+				hi = s
 			}
 		}
 	}
+	if hi < lo {
+		return
+	}
 
-	var wilds []WaysItemNew // wilds ways
-
-	scan := func(symbol symbol) (win WaysItemNew) {
-		win = WaysItemNew{Symbol: symbol, Ways: 1}
+	// dense symbol index when range is small, else by map
+	span := hi - lo + 1
+	var index map[symbol]int
+	if span > 4*width*64 {
+		index, span = map[symbol]int{}, 0
 		for _, reel := range grid {
-			count := 0
 			for _, s := range reel {
-				if s == symbol || s == wild {
-					count++
+				if _, ok := index[s]; !ok {
+					index[s] = span
+					span++
 				}
 			}
+		}
+	}
+
+	table := make([]waysCell, (width+1)*span) // cells by reel and symbol
+	wild := make([]waysCell, width)           // wild cells by reel
+	first := make([]symbol, 0, span)          // symbols in order of appearance
+	for i, reel := range grid {
+		for r, s := range reel {
+			if m := wildMulti(wilds, s); m > 0 {
+				wild[i].count++
+				wild[i].weight += m
+				wild[i].mask |= 1 << r
+				continue
+			}
+			k := s - lo
+			if index != nil {
+				k = index[s]
+			}
+			if seen := &table[width*span+k]; seen.count == 0 {
+				seen.count = 1
+				first = append(first, s)
+			}
+			c := &table[i*span+k]
+			c.count++
+			c.weight++
+			c.mask |= 1 << r
+		}
+	}
+
+	// backing arrays of results factors and masks
+	items := len(first) + 1
+	result = make([]WaysItemNew, 0, items)
+	factors, masks := make([]int, items*width), make([]uint, items*width)
+	next := func(win *WaysItemNew) {
+		k := len(result) * width
+		win.Factors, win.Mask = factors[k:k:k+width], masks[k:k+width:k+width]
+	}
+
+	// wilds only ways by width
+	var wildWays, wildMul []int
+	if len(wilds) > 0 {
+		win := WaysItemNew{Symbol: wilds[0].Symbol, Ways: 1, Multi: 1}
+		next(&win)
+		for _, c := range wild {
+			if c.count == 0 {
+				break
+			}
+			win.Ways *= c.count
+			win.Multi *= c.weight
+			win.Mask[len(win.Factors)] = c.mask
+			win.Factors = append(win.Factors, c.count)
+			wildWays, wildMul = append(wildWays, win.Ways), append(wildMul, win.Multi)
+		}
+		if win.Width = len(win.Factors); win.Width > 0 {
+			result = append(result, win)
+		}
+	}
+
+	for _, s := range first {
+		k := s - lo
+		if index != nil {
+			k = index[s]
+		}
+		win := WaysItemNew{Symbol: s, Ways: 1, Multi: 1}
+		next(&win)
+		for i := 0; i < width; i++ {
+			c := table[i*span+k]
+			count := c.count + wild[i].count
 			if count == 0 {
 				break
 			}
 			win.Ways *= count
+			win.Multi *= c.weight + wild[i].weight
 			win.Factors = append(win.Factors, count)
-			if symbol == wild {
-				wilds = append(wilds, win)
-			}
 		}
 		if win.Width = len(win.Factors); win.Width == 0 {
-			win.Ways = 0
+			continue
 		}
-		if symbol != wild {
-			if win.Width > 0 && win.Width <= len(wilds) {
-				win.Wilds = wilds[win.Width-1].Ways
-				win.Ways -= win.Wilds
-			}
+		if win.Width <= len(wildWays) {
+			win.Wilds = wildWays[win.Width-1]
+			win.Ways -= win.Wilds
+			win.Multi -= wildMul[win.Width-1]
 		}
-		if win.Ways > 0 && win.Width <= width {
+		if win.Ways > 0 {
+			for i := 0; i < win.Width; i++ {
+				win.Mask[i] = table[i*span+k].mask | wild[i].mask
+			}
 			result = append(result, win)
 		}
-		return
 	}
-
-	// scan symbols
-	scan(wild)
-	for symbol := range symbols {
-		scan(symbol)
-	}
-
 	return
 }
 
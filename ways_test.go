@@ -0,0 +1,148 @@
+package main
+
+// Author: Srbislav D. Nešić, srbislav.nesic@fincore.com
+
+import (
+	"DHSimulator/rng"
+	"fmt"
+	"testing"
+)
+
+// Reference ways evaluator, scans grid for each symbol (for checks and benchmark).
+func waysRescan(grid [][]symbol, wilds ...Wild) (result []WaysItemNew) {
+	width := len(grid)
+
+	multi := map[symbol]int{} // multipliers of wilds
+	for _, w := range wilds {
+		multi[w.Symbol] = w.multi()
+	}
+	symbols := map[symbol]int{} // list of symbols
+	for _, reel := range grid {
+		for _, symbol := range reel {
+			if _, wild := multi[symbol]; !wild {
+				symbols[symbol]++
+			}
+		}
+	}
+
+	var wildWays, wildMulti []int // wilds only ways by width
+
+	scan := func(symbol symbol, only bool) (win WaysItemNew) {
+		win = WaysItemNew{Symbol: symbol, Ways: 1, Multi: 1}
+		for _, reel := range grid {
+			count, weight := 0, 0
+			for _, s := range reel {
+				if m, wild := multi[s]; wild {
+					count++
+					weight += m
+				} else if s == symbol && !only {
+					count++
+					weight++
+				}
+			}
+			if count == 0 {
+				break
+			}
+			win.Ways *= count
+			win.Multi *= weight
+			win.Factors = append(win.Factors, count)
+			if only {
+				wildWays, wildMulti = append(wildWays, win.Ways), append(wildMulti, win.Multi)
+			}
+		}
+		if win.Width = len(win.Factors); win.Width == 0 {
+			win.Ways, win.Multi = 0, 0
+		}
+		if !only {
+			if win.Width > 0 && win.Width <= len(wildWays) {
+				win.Wilds = wildWays[win.Width-1]
+				win.Ways -= win.Wilds
+				win.Multi -= wildMulti[win.Width-1]
+			}
+		}
+		if win.Ways > 0 && win.Width <= width {
+			result = append(result, win)
+		}
+		return
+	}
+
+	// scan symbols
+	if len(wilds) > 0 {
+		scan(wilds[0].Symbol, true)
+	}
+	for symbol := range symbols {
+		scan(symbol, false)
+	}
+
+	return
+}
+
+// Grid of WaysTest (6 reels of 8 rows).
+var waysGrid = [][]symbol{
+	{0, 0, 0, 0, 18, 12, 14, 3}, {-1, -1, -1, -1, 18, 1, 4, 4}, {-2, -2, -2, -2, 18, 13, 13, 11},
+	{-3, -3, -3, -3, 18, 11, 9, 14}, {-4, -4, -4, -4, 18, 9, 14, 14}, {-5, -5, -5, -5, 18, 13, 11, 14},
+}
+
+// Random grids of 6 reels up to 12 symbols (Megaways heights 2 to 8).
+func megawaysGrids(n int) (grids [][][]symbol) {
+	var rnd rng.LCPRNG
+	rnd.Randomize(2024)
+	for g := 0; g < n; g++ {
+		grid := make([][]symbol, 6)
+		for i := range grid {
+			for r := rnd.Int(2, 8); r > 0; r-- {
+				grid[i] = append(grid[i], rnd.Choice(12)) // 0 and 1 are wilds
+			}
+		}
+		grids = append(grids, grid)
+	}
+	return
+}
+
+// Ways as set (up to order and masks).
+func waysKey(items []WaysItemNew) map[string]bool {
+	m := map[string]bool{}
+	for _, w := range items {
+		m[fmt.Sprint(w.Symbol, w.Factors, w.Width, w.Wilds, w.Ways, w.Multi)] = true
+	}
+	return m
+}
+
+// Single pass evaluator against rescanning on random windows.
+func TestWaysWilds(t *testing.T) {
+	for _, wilds := range [][]Wild{
+		{{Symbol: 0}},
+		{{Symbol: 0, Multi: 2}},
+		{{Symbol: 0, Multi: 3}, {Symbol: 1, Multi: 2}},
+	} {
+		for g, grid := range append(megawaysGrids(1000), waysGrid) {
+			a, b := waysKey(WaysWilds(grid, wilds...)), waysKey(waysRescan(grid, wilds...))
+			same := len(a) == len(b)
+			for k := range a {
+				same = same && b[k]
+			}
+			if !same {
+				t.Fatalf("wilds %v, grid %d %v:\nsingle pass %v\nrescan      %v", wilds, g, grid, a, b)
+			}
+		}
+	}
+}
+
+// Single pass and rescanning ways on 6x8 grid and Megaways grids.
+func BenchmarkWays(b *testing.B) {
+	for _, eval := range []struct {
+		name string
+		ways func(grid [][]symbol, wilds ...Wild) []WaysItemNew
+	}{{"single pass", WaysWilds}, {"rescan", waysRescan}} {
+		for _, set := range []struct {
+			name  string
+			grids [][][]symbol
+		}{{"6x8", [][][]symbol{waysGrid}}, {"megaways", megawaysGrids(1000)}} {
+			b.Run(eval.name+"/"+set.name, func(b *testing.B) {
+				for n := 0; n < b.N; n++ {
+					eval.ways(set.grids[n%len(set.grids)], Wild{Symbol: 18}, Wild{Symbol: 0})
+				}
+			})
+		}
+	}
+}